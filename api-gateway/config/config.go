@@ -11,6 +11,7 @@ import (
 type Config struct {
 	HTTPServer HTTPServer
 	Services   Microservices
+	Auth       Auth
 }
 
 type HTTPServer struct {
@@ -20,12 +21,20 @@ type HTTPServer struct {
 	IdleTimeout    time.Duration `env:"HTTP_IDLE_TIMEOUT" envDefault:"60s"`
 	MaxHeaderBytes int           `env:"HTTP_MAX_HEADER_BYTES" envDefault:"1048576"`
 	Mode           string        `env:"GIN_MODE" envDefault:"release"`
+	TrustedProxies []string      `env:"HTTP_TRUSTED_PROXIES" envSeparator:","` // proxies allowed to set X-Forwarded-For, none if empty
 }
 
 type Microservices struct {
 	UserService      ServiceConfig `envPrefix:"USER_SERVICE_"`
 	InventoryService ServiceConfig `envPrefix:"INVENTORY_SERVICE_"`
 	OrderService     ServiceConfig `envPrefix:"ORDER_SERVICE_"`
+	Token            string        `env:"SERVICE_TOKEN,required"` // presented to the services called, has to be listed in their GRPC_CALLERS
+}
+
+type Auth struct {
+	TokenIssuer           string        `env:"TOKEN_ISSUER" envDefault:"user-service"`
+	RequireStaffTwoFactor bool          `env:"AUTH_REQUIRE_STAFF_TWO_FACTOR" envDefault:"true"` // staff actions need a session confirmed with TOTP
+	SessionCacheTTL       time.Duration `env:"AUTH_SESSION_CACHE_TTL" envDefault:"15s"`         // revoked sessions are rejected within this time, 0 checks every request
 }
 
 type ServiceConfig struct {
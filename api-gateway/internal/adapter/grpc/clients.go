@@ -1,6 +1,7 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
 	"github.com/BeksultanSE/Assignment1-api-gateway/config"
 	proto "github.com/BeksultanSE/Assignment1-api-gateway/pkg/protos/gen/golang"
@@ -9,6 +10,9 @@ import (
 	"log"
 )
 
+// serviceName identifies this service to the services it calls
+const serviceName = "api-gateway"
+
 type Clients struct {
 	User      proto.AuthClient
 	Inventory proto.InventoryServiceClient
@@ -18,12 +22,14 @@ type Clients struct {
 
 func NewClients(cfg *config.Config) (*Clients, error) {
 	clients := &Clients{}
+	credentials := serviceCredentials{name: serviceName, token: cfg.Services.Token}
 
 	// User Service Client
 	userTarget := fmt.Sprintf("%s:%d", cfg.Services.UserService.Host, cfg.Services.UserService.Port)
 	userConn, err := grpc.NewClient(
 		userTarget,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(credentials),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to user service: %w", err)
@@ -36,6 +42,7 @@ func NewClients(cfg *config.Config) (*Clients, error) {
 	inventoryConn, err := grpc.NewClient(
 		inventoryTarget,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(credentials),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to inventory service: %w", err)
@@ -48,6 +55,7 @@ func NewClients(cfg *config.Config) (*Clients, error) {
 	orderConn, err := grpc.NewClient(
 		orderTarget,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(credentials),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to order service: %w", err)
@@ -66,3 +74,21 @@ func (c *Clients) Close() {
 		}
 	}
 }
+
+// serviceCredentials identify this service to the services it calls, they check the token against their GRPC_CALLERS
+type serviceCredentials struct {
+	name  string
+	token string
+}
+
+func (c serviceCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		"x-service-name":  c.name,
+		"x-service-token": c.token,
+	}, nil
+}
+
+// RequireTransportSecurity allows the token on the plaintext connections of the internal network
+func (c serviceCredentials) RequireTransportSecurity() bool {
+	return false
+}
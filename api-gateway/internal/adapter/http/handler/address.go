@@ -0,0 +1,162 @@
+package handler
+
+import (
+	proto "github.com/BeksultanSE/Assignment1-api-gateway/pkg/protos/gen/golang"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+	"net/http"
+	"strconv"
+)
+
+func (h *Handler) CreateAddress(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var req proto.CreateAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	req.UserId = userID.(uint64)
+
+	resp, err := h.Clients.User.CreateAddress(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) ListAddresses(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	req := &proto.UserID{UserId: userID.(uint64)}
+	resp, err := h.Clients.User.ListAddresses(c.Request.Context(), req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) GetAddress(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	addressID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid address ID"})
+		return
+	}
+
+	req := &proto.AddressRequest{UserId: userID.(uint64), AddressId: addressID}
+	resp, err := h.Clients.User.GetAddress(c.Request.Context(), req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) UpdateAddress(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	addressID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid address ID"})
+		return
+	}
+
+	var req proto.UpdateAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	req.UserId = userID.(uint64)
+	req.AddressId = addressID
+
+	resp, err := h.Clients.User.UpdateAddress(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) DeleteAddress(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	addressID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid address ID"})
+		return
+	}
+
+	req := &proto.AddressRequest{UserId: userID.(uint64), AddressId: addressID}
+	resp, err := h.Clients.User.DeleteAddress(c.Request.Context(), req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"errors"
 	grpc "github.com/BeksultanSE/Assignment1-api-gateway/internal/adapter/grpc"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"net/http"
+	"strconv"
 )
 
 type Handler struct {
@@ -32,13 +36,62 @@ func mapGRPCErrorToHTTP(err error) (int, string) {
 		return http.StatusNotFound, statusErr.Message()
 	case codes.AlreadyExists:
 		return http.StatusConflict, statusErr.Message()
+	case codes.FailedPrecondition:
+		return http.StatusConflict, statusErr.Message()
 	case codes.Unauthenticated:
 		return http.StatusUnauthorized, statusErr.Message()
 	case codes.PermissionDenied:
 		return http.StatusForbidden, statusErr.Message()
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests, statusErr.Message()
 	case codes.Unavailable:
 		return http.StatusServiceUnavailable, "target service is down"
 	default:
 		return http.StatusInternalServerError, statusErr.Message()
 	}
 }
+
+// grpcErrorBody builds the JSON error response for a failed call, field violations attached
+// by the services (e.g. a rejected password) are listed under "violations"
+func grpcErrorBody(err error, msg string) gin.H {
+	body := gin.H{"error": msg}
+
+	statusErr, ok := status.FromError(err)
+	if !ok {
+		return body
+	}
+
+	var violations []gin.H
+	for _, detail := range statusErr.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, violation := range badRequest.FieldViolations {
+			violations = append(violations, gin.H{
+				"field":       violation.Field,
+				"rule":        violation.Reason,
+				"description": violation.Description,
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		body["violations"] = violations
+	}
+	return body
+}
+
+// parseSkipTotal reads the skip_total parameter of the listings, clients walking pages by page_token
+// usually set it as counting every match gets slow on large collections
+func parseSkipTotal(c *gin.Context) (bool, error) {
+	value := c.Query("skip_total")
+	if value == "" {
+		return false, nil
+	}
+	skipTotal, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.New("invalid skip_total")
+	}
+	return skipTotal, nil
+}
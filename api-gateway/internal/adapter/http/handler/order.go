@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/json"
+	"github.com/BeksultanSE/Assignment1-api-gateway/internal/adapter/http/middleware"
 	protos "github.com/BeksultanSE/Assignment1-api-gateway/pkg/protos/gen/golang"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -16,16 +19,30 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 		return
 	}
 
-	var items []*protos.CreateOrderItem
-	if err := c.ShouldBindJSON(&items); err != nil {
+	// unverified accounts cannot place orders
+	profile, err := h.Clients.User.GetUserProfile(c.Request.Context(), &protos.UserID{UserId: userID.(uint64)})
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+	if !profile.EmailVerified {
+		c.JSON(http.StatusForbidden, gin.H{"error": "email address is not verified"})
+		return
+	}
+
+	var body createOrderBody
+	if err := bindCreateOrderBody(c, &body); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		log.Println(err)
 		return
 	}
 
 	req := &protos.CreateOrderRequest{
-		UserId: userID.(uint64),
-		Items:  items,
+		UserId:            userID.(uint64),
+		Items:             body.Items,
+		ShippingAddressId: body.ShippingAddressID,
+		BillingAddressId:  body.BillingAddressID,
 	}
 
 	resp, err := h.Clients.Order.CreateOrder(c.Request.Context(), req)
@@ -43,6 +60,28 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 
 	c.Data(http.StatusOK, "application/json", jsonBytes)
 }
+
+// createOrderBody is the payload of CreateOrder, omitted addresses default to the user's default addresses
+type createOrderBody struct {
+	Items             []*protos.CreateOrderItem `json:"items"`
+	ShippingAddressID uint64                    `json:"shipping_address_id"`
+	BillingAddressID  uint64                    `json:"billing_address_id"`
+}
+
+// bindCreateOrderBody also accepts a bare array of items as sent by clients written before addresses existed
+func bindCreateOrderBody(c *gin.Context, body *createOrderBody) error {
+	raw, err := c.GetRawData()
+	if err != nil {
+		return err
+	}
+
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		return json.Unmarshal(trimmed, &body.Items)
+	}
+	return json.Unmarshal(trimmed, body)
+}
+
 func (h *Handler) GetOrder(c *gin.Context) {
 	UserId, exists := c.Get("user_id")
 	if !exists {
@@ -69,7 +108,7 @@ func (h *Handler) GetOrder(c *gin.Context) {
 		return
 	}
 
-	if resp.UserId != UserId && UserId != 1 {
+	if resp.UserId != UserId && !middleware.HasPermission(c, middleware.PermissionOrderReadAll) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "invalid order ID, not allowed for current user"})
 		return
 	}
@@ -141,10 +180,18 @@ func (h *Handler) GetOrders(c *gin.Context) {
 		limit = 10
 	}
 
+	skipTotal, err := parseSkipTotal(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	req := &protos.ListOrdersRequest{
-		UserId: userID.(uint64),
-		Page:   page,
-		Limit:  limit,
+		UserId:    userID.(uint64),
+		Page:      page,
+		Limit:     limit,
+		PageToken: c.Query("page_token"),
+		SkipTotal: skipTotal,
 	}
 
 	resp, err := h.Clients.Order.ListOrders(c.Request.Context(), req)
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"fmt"
+	proto "github.com/BeksultanSE/Assignment1-api-gateway/pkg/protos/gen/golang"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/encoding/protojson"
+	"net/http"
+	"strconv"
+)
+
+// ExportMyData downloads everything stored about the caller, including the order history, as a JSON archive
+func (h *Handler) ExportMyData(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	h.exportData(c, userID.(uint64))
+}
+
+// ExportUserData downloads the data archive of any user, e.g. to answer a request made by mail
+func (h *Handler) ExportUserData(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := strconv.ParseUint(userIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	h.exportData(c, userID)
+}
+
+func (h *Handler) exportData(c *gin.Context, userID uint64) {
+	resp, err := h.Clients.User.ExportMyData(c.Request.Context(), &proto.UserID{UserId: userID})
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", resp.Filename))
+	c.Data(http.StatusOK, resp.ContentType, resp.Archive)
+}
+
+// EraseMyData anonymizes the caller's account, past orders are kept under a pseudonym
+func (h *Handler) EraseMyData(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	h.eraseUser(c, userID.(uint64))
+}
+
+func (h *Handler) EraseUser(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := strconv.ParseUint(userIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	h.eraseUser(c, userID)
+}
+
+func (h *Handler) eraseUser(c *gin.Context, userID uint64) {
+	resp, err := h.Clients.User.EraseUser(c.Request.Context(), &proto.UserID{UserId: userID})
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
@@ -1,12 +1,14 @@
 package handler
 
 import (
+	"errors"
 	proto "github.com/BeksultanSE/Assignment1-api-gateway/pkg/protos/gen/golang"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/protobuf/encoding/protojson"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 func (h *Handler) CreateProduct(c *gin.Context) {
@@ -109,11 +111,148 @@ func (h *Handler) ListProducts(c *gin.Context) {
 	}
 
 	req := &proto.ListProductsRequest{
+		Page:      page,
+		Limit:     limit,
+		PageToken: c.Query("page_token"),
+		SortBy:    c.Query("sort_by"),
+		SortOrder: c.Query("sort_order"),
+	}
+	if err := parseProductListFilters(c, req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.SkipTotal, err = parseSkipTotal(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.Clients.Inventory.ListProducts(c.Request.Context(), req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+// parseProductListFilters reads the optional filters of the product listing. Categories can be repeated or
+// comma-separated, e.g. ?category=sport&category=naked or ?category=sport,naked
+func parseProductListFilters(c *gin.Context, req *proto.ListProductsRequest) error {
+	if name := c.Query("name"); name != "" {
+		req.Name = &name
+	}
+
+	for _, value := range c.QueryArray("category") {
+		for _, category := range strings.Split(value, ",") {
+			if category = strings.TrimSpace(category); category != "" {
+				req.Categories = append(req.Categories, category)
+			}
+		}
+	}
+
+	if value := c.Query("price_min"); value != "" {
+		priceMin, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New("invalid price_min")
+		}
+		req.PriceMin = &priceMin
+	}
+
+	if value := c.Query("price_max"); value != "" {
+		priceMax, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New("invalid price_max")
+		}
+		req.PriceMax = &priceMax
+	}
+
+	if value := c.Query("in_stock_only"); value != "" {
+		inStockOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("invalid in_stock_only")
+		}
+		req.InStockOnly = inStockOnly
+	}
+
+	return parseMotorcycleSpecFilters(c, req)
+}
+
+// parseMotorcycleSpecFilters reads the filters on motorcycle specs, e.g.
+// ?engine_cc_min=300&engine_cc_max=700&license_class=A2. License classes can be repeated or comma-separated.
+func parseMotorcycleSpecFilters(c *gin.Context, req *proto.ListProductsRequest) error {
+	if brand := c.Query("brand"); brand != "" {
+		req.Brand = &brand
+	}
+
+	bounds := []struct {
+		param string
+		value **uint32
+	}{
+		{"engine_cc_min", &req.EngineCcMin},
+		{"engine_cc_max", &req.EngineCcMax},
+		{"model_year_min", &req.ModelYearMin},
+		{"model_year_max", &req.ModelYearMax},
+	}
+	for _, bound := range bounds {
+		value := c.Query(bound.param)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return errors.New("invalid " + bound.param)
+		}
+		number := uint32(parsed)
+		*bound.value = &number
+	}
+
+	for _, value := range c.QueryArray("license_class") {
+		for _, class := range strings.Split(value, ",") {
+			if class = strings.TrimSpace(class); class != "" {
+				req.LicenseClasses = append(req.LicenseClasses, class)
+			}
+		}
+	}
+
+	if transmission := c.Query("transmission"); transmission != "" {
+		req.Transmission = &transmission
+	}
+
+	return nil
+}
+
+// SearchProducts looks up products by the words of the q parameter, best matches first
+func (h *Handler) SearchProducts(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
+
+	page, err := strconv.ParseInt(c.Query("page"), 10, 64)
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.ParseInt(c.Query("limit"), 10, 64)
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	req := &proto.SearchProductsRequest{
+		Query: query,
 		Page:  page,
 		Limit: limit,
 	}
 
-	resp, err := h.Clients.Inventory.ListProducts(c.Request.Context(), req)
+	resp, err := h.Clients.Inventory.SearchProducts(c.Request.Context(), req)
 	if err != nil {
 		code, msg := mapGRPCErrorToHTTP(err)
 		c.JSON(code, gin.H{"error": msg})
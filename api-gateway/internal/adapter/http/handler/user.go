@@ -5,6 +5,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"google.golang.org/protobuf/encoding/protojson"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 func (h *Handler) RegisterUser(c *gin.Context) {
@@ -17,7 +19,7 @@ func (h *Handler) RegisterUser(c *gin.Context) {
 	resp, err := h.Clients.User.RegisterUser(c.Request.Context(), &req)
 	if err != nil {
 		code, msg := mapGRPCErrorToHTTP(err)
-		c.JSON(code, gin.H{"error": msg})
+		c.JSON(code, grpcErrorBody(err, msg))
 		return
 	}
 
@@ -53,3 +55,696 @@ func (h *Handler) GetUserProfile(c *gin.Context) {
 
 	c.Data(http.StatusOK, "application/json", jsonBytes)
 }
+
+func (h *Handler) Login(c *gin.Context) {
+	var req proto.AuthRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	resp, err := h.Clients.User.Login(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) RefreshToken(c *gin.Context) {
+	var req proto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	resp, err := h.Clients.User.RefreshToken(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) UpdateUserRole(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := strconv.ParseUint(userIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	var req proto.UpdateUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	req.UserId = userID
+
+	resp, err := h.Clients.User.UpdateUserRole(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) UpdateUserProfile(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var req proto.UpdateUserProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	req.UserId = userID.(uint64)
+
+	resp, err := h.Clients.User.UpdateUserProfile(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) ChangePassword(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var req proto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	req.UserId = userID.(uint64)
+
+	resp, err := h.Clients.User.ChangePassword(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, grpcErrorBody(err, msg))
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) DeleteMyAccount(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	h.deleteUser(c, userID.(uint64))
+}
+
+func (h *Handler) DeleteUser(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := strconv.ParseUint(userIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	h.deleteUser(c, userID)
+}
+
+func (h *Handler) deleteUser(c *gin.Context, userID uint64) {
+	resp, err := h.Clients.User.DeleteUser(c.Request.Context(), &proto.UserID{UserId: userID})
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) RequestPasswordReset(c *gin.Context) {
+	var req proto.RequestPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	resp, err := h.Clients.User.RequestPasswordReset(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) ResetPassword(c *gin.Context) {
+	var req proto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	resp, err := h.Clients.User.ResetPassword(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, grpcErrorBody(err, msg))
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	var req proto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	resp, err := h.Clients.User.VerifyEmail(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) ResendVerificationEmail(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	req := &proto.UserID{UserId: userID.(uint64)}
+	resp, err := h.Clients.User.ResendVerificationEmail(c.Request.Context(), req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) UnlockAccount(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := strconv.ParseUint(userIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	resp, err := h.Clients.User.UnlockAccount(c.Request.Context(), &proto.UserID{UserId: userID})
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+// ListUsers lists users for support staff. Supported query parameters: q (name or email fragment), role,
+// created_from and created_to (RFC3339 or YYYY-MM-DD, inclusive), sort_by (id, name, email, created_at),
+// order (asc or desc), page and limit.
+func (h *Handler) ListUsers(c *gin.Context) {
+	page, err := strconv.ParseInt(c.Query("page"), 10, 64)
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.ParseInt(c.Query("limit"), 10, 64)
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	req := &proto.ListUsersRequest{
+		SortBy: c.Query("sort_by"),
+		Page:   page,
+		Limit:  limit,
+	}
+
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		req.SortDesc = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "order must be asc or desc"})
+		return
+	}
+
+	if search, ok := c.GetQuery("q"); ok {
+		req.Search = &search
+	}
+	if role, ok := c.GetQuery("role"); ok {
+		req.Role = &role
+	}
+	if value, ok := c.GetQuery("created_from"); ok {
+		from, err := parseDateQuery(value, false)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid created_from"})
+			return
+		}
+		req.CreatedFrom = &from
+	}
+	if value, ok := c.GetQuery("created_to"); ok {
+		to, err := parseDateQuery(value, true)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid created_to"})
+			return
+		}
+		req.CreatedTo = &to
+	}
+
+	resp, err := h.Clients.User.ListUsers(c.Request.Context(), req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+// parseDateQuery parses an RFC3339 timestamp or a plain date into unix seconds,
+// a plain date used as an upper bound covers the whole day
+func parseDateQuery(value string, endOfDay bool) (int64, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Unix(), nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return 0, err
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+	return t.Unix(), nil
+}
+
+func (h *Handler) VerifyTwoFactorLogin(c *gin.Context) {
+	var req proto.VerifyTwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	resp, err := h.Clients.User.VerifyTwoFactorLogin(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) EnrollTwoFactor(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	req := &proto.UserID{UserId: userID.(uint64)}
+	resp, err := h.Clients.User.EnrollTwoFactor(c.Request.Context(), req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) ConfirmTwoFactor(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var req proto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	req.UserId = userID.(uint64)
+
+	resp, err := h.Clients.User.ConfirmTwoFactor(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) DisableTwoFactor(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var req proto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	req.UserId = userID.(uint64)
+
+	resp, err := h.Clients.User.DisableTwoFactor(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	var req proto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	req.UserId = userID.(uint64)
+
+	resp, err := h.Clients.User.CreateAPIKey(c.Request.Context(), &req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) ListAPIKeys(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	req := &proto.UserID{UserId: userID.(uint64)}
+	resp, err := h.Clients.User.ListAPIKeys(c.Request.Context(), req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	keyIDStr := c.Param("id")
+	keyID, err := strconv.ParseUint(keyIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid API key ID"})
+		return
+	}
+
+	req := &proto.RevokeAPIKeyRequest{UserId: userID.(uint64), KeyId: keyID}
+	resp, err := h.Clients.User.RevokeAPIKey(c.Request.Context(), req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) ListSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	req := &proto.UserID{UserId: userID.(uint64)}
+	resp, err := h.Clients.User.ListSessions(c.Request.Context(), req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+func (h *Handler) RevokeSession(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	req := &proto.RevokeSessionRequest{UserId: userID.(uint64), SessionId: c.Param("id")}
+	h.revokeSession(c, req)
+}
+
+// Logout revokes the session of the access token used for the request
+func (h *Handler) Logout(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	sessionID := c.GetString("session_id")
+	if sessionID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "request is not authenticated with a session"})
+		return
+	}
+
+	req := &proto.RevokeSessionRequest{UserId: userID.(uint64), SessionId: sessionID}
+	h.revokeSession(c, req)
+}
+
+func (h *Handler) revokeSession(c *gin.Context, req *proto.RevokeSessionRequest) {
+	resp, err := h.Clients.User.RevokeSession(c.Request.Context(), req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
+
+// RevokeAllSessions signs the caller out on all devices, the current session is kept unless keep_current=false
+func (h *Handler) RevokeAllSessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not authenticated"})
+		return
+	}
+
+	keepCurrent, err := strconv.ParseBool(c.DefaultQuery("keep_current", "true"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid keep_current"})
+		return
+	}
+
+	req := &proto.RevokeAllSessionsRequest{UserId: userID.(uint64), KeepCurrent: keepCurrent}
+	h.revokeAllSessions(c, req)
+}
+
+// ForceLogout signs a user out on all devices, e.g. after their account was compromised
+func (h *Handler) ForceLogout(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := strconv.ParseUint(userIDStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	req := &proto.RevokeAllSessionsRequest{UserId: userID}
+	h.revokeAllSessions(c, req)
+}
+
+func (h *Handler) revokeAllSessions(c *gin.Context, req *proto.RevokeAllSessionsRequest) {
+	resp, err := h.Clients.User.RevokeAllSessions(c.Request.Context(), req)
+	if err != nil {
+		code, msg := mapGRPCErrorToHTTP(err)
+		c.JSON(code, gin.H{"error": msg})
+		return
+	}
+
+	jsonBytes, err := protojson.Marshal(resp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json", jsonBytes)
+}
@@ -1,47 +1,60 @@
 package middleware
 
 import (
-	proto "github.com/BeksultanSE/Assignment1-api-gateway/pkg/protos/gen/golang"
+	"errors"
+	"github.com/BeksultanSE/Assignment1-api-gateway/internal/adapter/token"
 	"github.com/gin-gonic/gin"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/metadata"
+	"strconv"
+	"strings"
 )
 
-func AuthMiddleware(userClient proto.AuthClient) gin.HandlerFunc {
+const (
+	bearerPrefix = "Bearer "
+	apiKeyPrefix = "ApiKey "
+)
+
+// AuthMiddleware accepts either "Authorization: Bearer <access token>" or "Authorization: ApiKey <key>"
+func AuthMiddleware(verifier *token.Verifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		email := c.GetHeader("X-Email")
-		password := c.GetHeader("X-Password")
+		header := c.GetHeader("Authorization")
 
-		if email == "" || password == "" {
-			c.JSON(400, gin.H{"error": "X-Email and X-Password headers are required"})
+		var claims token.Claims
+		var err error
+		if accessToken, ok := strings.CutPrefix(header, bearerPrefix); ok && accessToken != "" {
+			claims, err = verifier.Verify(c.Request.Context(), accessToken)
+		} else if apiKey, ok := strings.CutPrefix(header, apiKeyPrefix); ok && apiKey != "" {
+			claims, err = verifier.VerifyAPIKey(c.Request.Context(), apiKey)
+		} else {
+			c.JSON(401, gin.H{"error": "Authorization header with a Bearer token or an ApiKey is required"})
 			c.Abort()
 			return
 		}
 
-		authReq := &proto.AuthRequest{
-			Email:    email,
-			Password: password,
-		}
-
-		authResp, err := userClient.AuthenticateUser(c.Request.Context(), authReq)
 		if err != nil {
-			st, ok := status.FromError(err)
-			if ok && st.Code() == codes.Unauthenticated {
-				c.JSON(401, gin.H{"error": "authentication failed"})
+			if errors.Is(err, token.ErrKeyUnavailable) || errors.Is(err, token.ErrAuthUnavailable) {
+				c.JSON(503, gin.H{"error": "authentication service is unavailable"})
 			} else {
-				c.JSON(500, gin.H{"error": "internal server error"})
+				c.JSON(401, gin.H{"error": "authentication failed"})
 			}
 			c.Abort()
 			return
 		}
 
-		if !authResp.Authenticated {
-			c.JSON(401, gin.H{"error": "authentication failed"})
-			c.Abort()
-			return
-		}
+		c.Set("user_id", claims.UserID)
+		c.Set("user_role", claims.Role)
+		c.Set("two_factor", claims.TwoFactor)
+		c.Set("session_id", claims.SessionID)
 
-		c.Set("user_id", authResp.UserId)
+		// forward the verified identity to downstream services
+		identity := []string{
+			"x-user-id", strconv.FormatUint(claims.UserID, 10),
+			"x-user-role", claims.Role,
+		}
+		if claims.SessionID != "" {
+			identity = append(identity, "x-session-id", claims.SessionID)
+		}
+		c.Request = c.Request.WithContext(metadata.AppendToOutgoingContext(c.Request.Context(), identity...))
 		c.Next()
 	}
 }
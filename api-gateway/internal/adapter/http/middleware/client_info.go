@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/metadata"
+)
+
+// ForwardClientInfo passes the client address and user agent to downstream services,
+// e.g. for login rate limiting and to describe sessions
+func ForwardClientInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(metadata.AppendToOutgoingContext(
+			c.Request.Context(),
+			"x-client-ip", c.ClientIP(),
+			"x-user-agent", c.Request.UserAgent(),
+		))
+		c.Next()
+	}
+}
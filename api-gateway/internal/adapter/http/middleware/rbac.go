@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"slices"
+)
+
+type Permission string
+
+const (
+	PermissionCatalogManage     Permission = "catalog:manage"
+	PermissionOrderStatusManage Permission = "orders:manage_status"
+	PermissionOrderReadAll      Permission = "orders:read_all"
+	PermissionUserManage        Permission = "users:manage"
+)
+
+// rolePermissions maps each user role to the permissions it is granted
+var rolePermissions = map[string][]Permission{
+	"customer": {},
+	"sales_staff": {
+		PermissionCatalogManage,
+		PermissionOrderStatusManage,
+		PermissionOrderReadAll,
+	},
+	"warehouse": {
+		PermissionOrderStatusManage,
+		PermissionOrderReadAll,
+	},
+	"admin": {
+		PermissionCatalogManage,
+		PermissionOrderStatusManage,
+		PermissionOrderReadAll,
+		PermissionUserManage,
+	},
+}
+
+// staffPermissions allow changing prices, order statuses or accounts, with RequireTwoFactor
+// they are only usable from sessions confirmed with a second factor
+var staffPermissions = []Permission{
+	PermissionCatalogManage,
+	PermissionOrderStatusManage,
+	PermissionUserManage,
+}
+
+// IsStaffPermission reports whether the permission is one of the staff permissions
+func IsStaffPermission(permission Permission) bool {
+	return slices.Contains(staffPermissions, permission)
+}
+
+// HasPermission reports whether the authenticated user's role grants the permission
+func HasPermission(c *gin.Context, permission Permission) bool {
+	role := c.GetString("user_role")
+	return slices.Contains(rolePermissions[role], permission)
+}
+
+// RequirePermission must run after AuthMiddleware, it aborts with 403 when the role lacks the permission
+func RequirePermission(permission Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(c, permission) {
+			c.JSON(403, gin.H{"error": "insufficient permissions"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireTwoFactor must run after AuthMiddleware, it aborts with 403 when the session was not confirmed with a second factor
+func RequireTwoFactor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("two_factor") {
+			c.JSON(403, gin.H{"error": "two-factor authentication is required for this action"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
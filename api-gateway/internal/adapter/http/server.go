@@ -7,6 +7,7 @@ import (
 	"github.com/BeksultanSE/Assignment1-api-gateway/config"
 	"github.com/BeksultanSE/Assignment1-api-gateway/internal/adapter/http/handler"
 	"github.com/BeksultanSE/Assignment1-api-gateway/internal/adapter/http/middleware"
+	"github.com/BeksultanSE/Assignment1-api-gateway/internal/adapter/token"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"log"
@@ -23,15 +24,24 @@ type Server struct {
 	httpServer *gin.Engine
 	cfg        config.HTTPServer
 
-	address string
-	handler *handler.Handler
+	address  string
+	handler  *handler.Handler
+	verifier *token.Verifier
+
+	requireStaffTwoFactor bool
 }
 
-func NewServer(cfg config.Config, handler *handler.Handler) *Server {
+func NewServer(cfg config.Config, handler *handler.Handler, verifier *token.Verifier) *Server {
 	gin.SetMode(cfg.HTTPServer.Mode)
 
 	r := gin.New()
 
+	// client IPs feed the login lockout, so forwarded headers are only honoured from known proxies
+	if err := r.SetTrustedProxies(cfg.HTTPServer.TrustedProxies); err != nil {
+		log.Printf("Invalid trusted proxies, forwarded headers are ignored: %v", err)
+		_ = r.SetTrustedProxies(nil)
+	}
+
 	r.Use(gin.Recovery())
 	r.Use(gin.Logger())
 
@@ -40,6 +50,9 @@ func NewServer(cfg config.Config, handler *handler.Handler) *Server {
 		cfg:        cfg.HTTPServer,
 		address:    fmt.Sprintf(serverIPAddress, cfg.HTTPServer.Port),
 		handler:    handler,
+		verifier:   verifier,
+
+		requireStaffTwoFactor: cfg.Auth.RequireStaffTwoFactor,
 	}
 
 	api.setupRoutes()
@@ -55,24 +68,78 @@ func (s *Server) setupRoutes() {
 
 	//api routes setup
 	v1 := s.httpServer.Group("/api/v1")
+	v1.Use(middleware.ForwardClientInfo())
 
 	v1.POST("/users/register", s.handler.RegisterUser)
-	v1.GET("/users/profile", middleware.AuthMiddleware(s.handler.Clients.User), s.handler.GetUserProfile)
-
-	v1.GET("/products", s.handler.ListProducts) // Public endpoint
+	v1.POST("/users/login", s.handler.Login)
+	v1.POST("/users/refresh", s.handler.RefreshToken)
+	v1.POST("/users/login/2fa", s.handler.VerifyTwoFactorLogin)
+	v1.POST("/users/password/forgot", s.handler.RequestPasswordReset)
+	v1.POST("/users/password/reset", s.handler.ResetPassword)
+	v1.POST("/users/verify-email", s.handler.VerifyEmail)
+	v1.GET("/users/profile", middleware.AuthMiddleware(s.verifier), s.handler.GetUserProfile)
+
+	v1.GET("/products", s.handler.ListProducts)          // Public endpoint
+	v1.GET("/products/search", s.handler.SearchProducts) // Public endpoint
+
+	// permission table for authenticated routes, an empty permission only requires a valid token
+	protectedRoutes := []struct {
+		method     string
+		path       string
+		permission middleware.Permission
+		handler    gin.HandlerFunc
+	}{
+		{http.MethodPut, "/users/profile", "", s.handler.UpdateUserProfile},
+		{http.MethodPut, "/users/password", "", s.handler.ChangePassword},
+		{http.MethodDelete, "/users/profile", "", s.handler.DeleteMyAccount},
+		{http.MethodPost, "/users/verify-email/resend", "", s.handler.ResendVerificationEmail},
+		{http.MethodPost, "/users/2fa/enroll", "", s.handler.EnrollTwoFactor},
+		{http.MethodPost, "/users/2fa/confirm", "", s.handler.ConfirmTwoFactor},
+		{http.MethodPost, "/users/2fa/disable", "", s.handler.DisableTwoFactor},
+		{http.MethodPost, "/users/api-keys", "", s.handler.CreateAPIKey},
+		{http.MethodGet, "/users/api-keys", "", s.handler.ListAPIKeys},
+		{http.MethodDelete, "/users/api-keys/:id", "", s.handler.RevokeAPIKey},
+		{http.MethodPost, "/users/logout", "", s.handler.Logout},
+		{http.MethodGet, "/users/sessions", "", s.handler.ListSessions},
+		{http.MethodDelete, "/users/sessions", "", s.handler.RevokeAllSessions},
+		{http.MethodDelete, "/users/sessions/:id", "", s.handler.RevokeSession},
+		{http.MethodPost, "/users/addresses", "", s.handler.CreateAddress},
+		{http.MethodGet, "/users/addresses", "", s.handler.ListAddresses},
+		{http.MethodGet, "/users/addresses/:id", "", s.handler.GetAddress},
+		{http.MethodPut, "/users/addresses/:id", "", s.handler.UpdateAddress},
+		{http.MethodDelete, "/users/addresses/:id", "", s.handler.DeleteAddress},
+		{http.MethodGet, "/users/data-export", "", s.handler.ExportMyData},
+		{http.MethodPost, "/users/erase", "", s.handler.EraseMyData},
+		{http.MethodGet, "/users", middleware.PermissionUserManage, s.handler.ListUsers},
+		{http.MethodPut, "/users/:id/role", middleware.PermissionUserManage, s.handler.UpdateUserRole},
+		{http.MethodPost, "/users/:id/unlock", middleware.PermissionUserManage, s.handler.UnlockAccount},
+		{http.MethodPost, "/users/:id/logout", middleware.PermissionUserManage, s.handler.ForceLogout},
+		{http.MethodGet, "/users/:id/data-export", middleware.PermissionUserManage, s.handler.ExportUserData},
+		{http.MethodPost, "/users/:id/erase", middleware.PermissionUserManage, s.handler.EraseUser},
+		{http.MethodDelete, "/users/:id", middleware.PermissionUserManage, s.handler.DeleteUser},
+
+		{http.MethodPost, "/products", middleware.PermissionCatalogManage, s.handler.CreateProduct},
+		{http.MethodGet, "/products/:id", "", s.handler.GetProduct},
+		{http.MethodPut, "/products/:id", middleware.PermissionCatalogManage, s.handler.UpdateProduct},
+		{http.MethodDelete, "/products/:id", middleware.PermissionCatalogManage, s.handler.DeleteProduct},
+
+		{http.MethodPost, "/orders", "", s.handler.CreateOrder},
+		{http.MethodGet, "/orders", "", s.handler.GetOrders},
+		{http.MethodGet, "/orders/:id", "", s.handler.GetOrder},
+		{http.MethodPut, "/orders/:id", middleware.PermissionOrderStatusManage, s.handler.UpdateOrder},
+	}
 
 	protected := v1.Group("/")
-	protected.Use(middleware.AuthMiddleware(s.handler.Clients.User))
-	{
-		protected.POST("/products", s.handler.CreateProduct)
-		protected.GET("/products/:id", s.handler.GetProduct)
-		protected.PUT("/products/:id", s.handler.UpdateProduct)
-		protected.DELETE("/products/:id", s.handler.DeleteProduct)
-
-		protected.POST("/orders", s.handler.CreateOrder)
-		protected.GET("/orders", s.handler.GetOrders)
-		protected.GET("/orders/:id", s.handler.GetOrder)
-		protected.PUT("/orders/:id", s.handler.UpdateOrder)
+	protected.Use(middleware.AuthMiddleware(s.verifier))
+	for _, route := range protectedRoutes {
+		handlers := []gin.HandlerFunc{route.handler}
+		if route.permission != "" {
+			handlers = append([]gin.HandlerFunc{middleware.RequirePermission(route.permission)}, handlers...)
+			if s.requireStaffTwoFactor && middleware.IsStaffPermission(route.permission) {
+				handlers = append([]gin.HandlerFunc{middleware.RequireTwoFactor()}, handlers...)
+			}
+		}
+		protected.Handle(route.method, route.path, handlers...)
 	}
 	s.httpServer.NoRoute(func(c *gin.Context) {
 		log.Printf("No route matched: %s %s", c.Request.Method, c.Request.URL.String())
@@ -0,0 +1,24 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	proto "github.com/BeksultanSE/Assignment1-api-gateway/pkg/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VerifyAPIKey resolves an API key through the user-service, unlike access tokens keys are not
+// self-contained so every request is checked remotely, which also lets revocations apply immediately
+func (v *Verifier) VerifyAPIKey(ctx context.Context, apiKey string) (Claims, error) {
+	resp, err := v.client.ValidateAPIKey(ctx, &proto.ValidateAPIKeyRequest{ApiKey: apiKey})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated || status.Code(err) == codes.InvalidArgument {
+			return Claims{}, ErrInvalidToken
+		}
+		return Claims{}, fmt.Errorf("%w: %v", ErrAuthUnavailable, err)
+	}
+
+	// API keys are not confirmed with a second factor
+	return Claims{UserID: resp.UserId, Role: resp.Role}, nil
+}
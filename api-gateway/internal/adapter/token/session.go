@@ -0,0 +1,92 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	proto "github.com/BeksultanSE/Assignment1-api-gateway/pkg/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+	"time"
+)
+
+// maxCachedSessions bounds the session cache, expired entries are swept once it is reached
+const maxCachedSessions = 10000
+
+type sessionCheck struct {
+	userID    uint64
+	active    bool
+	checkedAt time.Time
+}
+
+// sessionCache remembers recent session checks so that not every request reaches the user-service,
+// a revoked session is rejected at the latest ttl after the revocation
+type sessionCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]sessionCheck
+}
+
+func newSessionCache(ttl time.Duration) *sessionCache {
+	return &sessionCache{
+		ttl:     ttl,
+		entries: make(map[string]sessionCheck),
+	}
+}
+
+func (c *sessionCache) get(sessionID string, now time.Time) (sessionCheck, bool) {
+	if c.ttl <= 0 {
+		return sessionCheck{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	check, ok := c.entries[sessionID]
+	if !ok || now.Sub(check.checkedAt) >= c.ttl {
+		return sessionCheck{}, false
+	}
+	return check, true
+}
+
+func (c *sessionCache) put(sessionID string, check sessionCheck) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxCachedSessions {
+		for id, entry := range c.entries {
+			if check.checkedAt.Sub(entry.checkedAt) >= c.ttl {
+				delete(c.entries, id)
+			}
+		}
+	}
+	c.entries[sessionID] = check
+}
+
+// checkSession rejects access tokens whose session has been revoked or has expired
+func (v *Verifier) checkSession(ctx context.Context, sessionID string, userID uint64) error {
+	now := time.Now()
+	check, ok := v.sessions.get(sessionID, now)
+	if !ok {
+		resp, err := v.client.ValidateSession(ctx, &proto.ValidateSessionRequest{SessionId: sessionID})
+		switch {
+		case err == nil:
+			check = sessionCheck{userID: resp.UserId, active: true, checkedAt: now}
+		case status.Code(err) == codes.Unauthenticated:
+			check = sessionCheck{active: false, checkedAt: now}
+		default:
+			return fmt.Errorf("%w: %v", ErrAuthUnavailable, err)
+		}
+		v.sessions.put(sessionID, check)
+	}
+
+	if !check.active || check.userID != userID {
+		return ErrInvalidToken
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package token
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	proto "github.com/BeksultanSE/Assignment1-api-gateway/pkg/protos/gen/golang"
+	"github.com/golang-jwt/jwt/v5"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	algorithm       = "EdDSA"
+	tokenTypeAccess = "access"
+
+	// keyRefreshInterval is the minimum time between two fetches of the public key, so tokens
+	// with made up key ids cannot flood the user-service
+	keyRefreshInterval = 30 * time.Second
+)
+
+var (
+	ErrInvalidToken    = errors.New("invalid token")
+	ErrKeyUnavailable  = errors.New("token verification key is unavailable")
+	ErrAuthUnavailable = errors.New("authentication service is unavailable")
+)
+
+// Claims are the verified values taken from an access token
+type Claims struct {
+	UserID    uint64
+	Role      string
+	TwoFactor bool   // the login was confirmed with a second factor
+	SessionID string // empty for API keys
+}
+
+type accessClaims struct {
+	Type string `json:"type"`
+	Role string `json:"role"`
+	MFA  bool   `json:"mfa"`
+	SID  string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// Verifier checks access tokens locally with the public key published by the user-service.
+// The key is fetched lazily and fetched again when a token is signed with an unknown key id,
+// at most once per keyRefreshInterval.
+// Whether the session of the token is still active is checked with the user-service and cached
+// for a short time.
+type Verifier struct {
+	client   proto.AuthClient
+	issuer   string
+	sessions *sessionCache
+
+	mu    sync.RWMutex
+	keyID string
+	key   ed25519.PublicKey
+
+	// refreshMu serializes fetches of the key, lastRefresh is guarded by it
+	refreshMu   sync.Mutex
+	lastRefresh time.Time
+}
+
+func NewVerifier(client proto.AuthClient, issuer string, sessionCacheTTL time.Duration) *Verifier {
+	return &Verifier{
+		client:   client,
+		issuer:   issuer,
+		sessions: newSessionCache(sessionCacheTTL),
+	}
+}
+
+// Verify validates the signature, issuer, expiry and type of the access token and that its session is active
+func (v *Verifier) Verify(ctx context.Context, tokenStr string) (Claims, error) {
+	var claims accessClaims
+	_, err := jwt.ParseWithClaims(tokenStr, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.publicKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{algorithm}),
+		jwt.WithIssuer(v.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		if errors.Is(err, ErrKeyUnavailable) {
+			return Claims{}, err
+		}
+		return Claims{}, ErrInvalidToken
+	}
+
+	if claims.Type != tokenTypeAccess {
+		return Claims{}, ErrInvalidToken
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	// tokens issued before sessions were introduced have no session and expire on their own
+	if claims.SID != "" {
+		if err := v.checkSession(ctx, claims.SID, userID); err != nil {
+			return Claims{}, err
+		}
+	}
+
+	return Claims{UserID: userID, Role: claims.Role, TwoFactor: claims.MFA, SessionID: claims.SID}, nil
+}
+
+func (v *Verifier) publicKey(ctx context.Context, kid string) (ed25519.PublicKey, error) {
+	if key, ok := v.cachedKey(kid); ok {
+		return key, nil
+	}
+
+	// unknown key id, the user-service may have rotated its signing key
+	v.refreshMu.Lock()
+	defer v.refreshMu.Unlock()
+
+	// another request may have fetched the key while this one was waiting
+	if key, ok := v.cachedKey(kid); ok {
+		return key, nil
+	}
+
+	if time.Since(v.lastRefresh) < keyRefreshInterval {
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+		if v.key == nil {
+			return nil, ErrKeyUnavailable
+		}
+		return nil, ErrInvalidToken
+	}
+
+	v.lastRefresh = time.Now()
+	if err := v.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	if key, ok := v.cachedKey(kid); ok {
+		return key, nil
+	}
+	return nil, ErrInvalidToken
+}
+
+func (v *Verifier) cachedKey(kid string) (ed25519.PublicKey, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.key == nil || v.keyID != kid {
+		return nil, false
+	}
+	return v.key, true
+}
+
+func (v *Verifier) refresh(ctx context.Context) error {
+	resp, err := v.client.GetPublicKey(ctx, &proto.PublicKeyRequest{})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrKeyUnavailable, err)
+	}
+
+	if resp.Algorithm != algorithm {
+		return fmt.Errorf("%w: unsupported algorithm %s", ErrKeyUnavailable, resp.Algorithm)
+	}
+
+	block, _ := pem.Decode([]byte(resp.PublicKey))
+	if block == nil {
+		return fmt.Errorf("%w: no PEM block found", ErrKeyUnavailable)
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrKeyUnavailable, err)
+	}
+
+	key, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w: public key is not an ed25519 key", ErrKeyUnavailable)
+	}
+
+	v.mu.Lock()
+	v.keyID = resp.KeyId
+	v.key = key
+	v.mu.Unlock()
+
+	return nil
+}
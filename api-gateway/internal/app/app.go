@@ -7,6 +7,7 @@ import (
 	"github.com/BeksultanSE/Assignment1-api-gateway/internal/adapter/grpc"
 	"github.com/BeksultanSE/Assignment1-api-gateway/internal/adapter/http"
 	handlers "github.com/BeksultanSE/Assignment1-api-gateway/internal/adapter/http/handler"
+	"github.com/BeksultanSE/Assignment1-api-gateway/internal/adapter/token"
 	"log"
 	"os"
 	"os/signal"
@@ -31,7 +32,9 @@ func New(ctx context.Context, cfg *config.Config) (*App, error) {
 
 	handler := handlers.NewHandler(grpcClients)
 
-	httpServer := http.NewServer(*cfg, handler)
+	verifier := token.NewVerifier(grpcClients.User, cfg.Auth.TokenIssuer, cfg.Auth.SessionCacheTTL)
+
+	httpServer := http.NewServer(*cfg, handler, verifier)
 
 	app := &App{
 		cfg:         cfg,
@@ -22,11 +22,13 @@ const (
 )
 
 type CreateOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*CreateOrderItem     `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	UserId            uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items             []*CreateOrderItem     `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	ShippingAddressId uint64                 `protobuf:"varint,3,opt,name=shipping_address_id,json=shippingAddressId,proto3" json:"shipping_address_id,omitempty"` // address book entry of the user, defaults to the default shipping address
+	BillingAddressId  uint64                 `protobuf:"varint,4,opt,name=billing_address_id,json=billingAddressId,proto3" json:"billing_address_id,omitempty"`    // defaults to the default billing address, then to the shipping address
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *CreateOrderRequest) Reset() {
@@ -73,6 +75,20 @@ func (x *CreateOrderRequest) GetItems() []*CreateOrderItem {
 	return nil
 }
 
+func (x *CreateOrderRequest) GetShippingAddressId() uint64 {
+	if x != nil {
+		return x.ShippingAddressId
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetBillingAddressId() uint64 {
+	if x != nil {
+		return x.BillingAddressId
+	}
+	return 0
+}
+
 type CreateOrderItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
@@ -306,16 +322,19 @@ func (x *UpdateOrderRequest) GetStatus() string {
 }
 
 type OrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	UserId        uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
-	TotalAmount   float64                `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OrderId         uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId          uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items           []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	TotalAmount     float64                `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	Status          string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt       string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ShippingAddress *OrderAddress          `protobuf:"bytes,8,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	BillingAddress  *OrderAddress          `protobuf:"bytes,9,opt,name=billing_address,json=billingAddress,proto3" json:"billing_address,omitempty"`
+	StatusReason    string                 `protobuf:"bytes,10,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"` // why a cancelled order has been rejected by inventory, e.g. insufficient_stock
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *OrderResponse) Reset() {
@@ -397,18 +416,150 @@ func (x *OrderResponse) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *OrderResponse) GetShippingAddress() *OrderAddress {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *OrderResponse) GetBillingAddress() *OrderAddress {
+	if x != nil {
+		return x.BillingAddress
+	}
+	return nil
+}
+
+func (x *OrderResponse) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+// OrderAddress is a copy of the address book entry taken when the order was placed
+type OrderAddress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AddressId     uint64                 `protobuf:"varint,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	RecipientName string                 `protobuf:"bytes,2,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	Phone         string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	Line1         string                 `protobuf:"bytes,4,opt,name=line1,proto3" json:"line1,omitempty"`
+	Line2         string                 `protobuf:"bytes,5,opt,name=line2,proto3" json:"line2,omitempty"`
+	City          string                 `protobuf:"bytes,6,opt,name=city,proto3" json:"city,omitempty"`
+	Region        string                 `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,8,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country       string                 `protobuf:"bytes,9,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderAddress) Reset() {
+	*x = OrderAddress{}
+	mi := &file_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderAddress) ProtoMessage() {}
+
+func (x *OrderAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderAddress.ProtoReflect.Descriptor instead.
+func (*OrderAddress) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OrderAddress) GetAddressId() uint64 {
+	if x != nil {
+		return x.AddressId
+	}
+	return 0
+}
+
+func (x *OrderAddress) GetRecipientName() string {
+	if x != nil {
+		return x.RecipientName
+	}
+	return ""
+}
+
+func (x *OrderAddress) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *OrderAddress) GetLine1() string {
+	if x != nil {
+		return x.Line1
+	}
+	return ""
+}
+
+func (x *OrderAddress) GetLine2() string {
+	if x != nil {
+		return x.Line2
+	}
+	return ""
+}
+
+func (x *OrderAddress) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *OrderAddress) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *OrderAddress) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *OrderAddress) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
 type ListOrdersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Page          int64                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
 	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`  // next_page_token of the previous page, page is ignored when set
+	SkipTotal     bool                   `protobuf:"varint,5,opt,name=skip_total,json=skipTotal,proto3" json:"skip_total,omitempty"` // total is not counted and left 0
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListOrdersRequest) Reset() {
 	*x = ListOrdersRequest{}
-	mi := &file_order_proto_msgTypes[6]
+	mi := &file_order_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -420,7 +571,7 @@ func (x *ListOrdersRequest) String() string {
 func (*ListOrdersRequest) ProtoMessage() {}
 
 func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[6]
+	mi := &file_order_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,7 +584,7 @@ func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
 func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{6}
+	return file_order_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ListOrdersRequest) GetUserId() uint64 {
@@ -457,17 +608,32 @@ func (x *ListOrdersRequest) GetLimit() int64 {
 	return 0
 }
 
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetSkipTotal() bool {
+	if x != nil {
+		return x.SkipTotal
+	}
+	return false
+}
+
 type ListOrdersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Orders        []*OrderResponse       `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
 	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListOrdersResponse) Reset() {
 	*x = ListOrdersResponse{}
-	mi := &file_order_proto_msgTypes[7]
+	mi := &file_order_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -479,7 +645,7 @@ func (x *ListOrdersResponse) String() string {
 func (*ListOrdersResponse) ProtoMessage() {}
 
 func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[7]
+	mi := &file_order_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,7 +658,7 @@ func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
 func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{7}
+	return file_order_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListOrdersResponse) GetOrders() []*OrderResponse {
@@ -509,14 +675,120 @@ func (x *ListOrdersResponse) GetTotal() int64 {
 	return 0
 }
 
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// PseudonymizeUserOrdersRequest detaches the orders from an erased user, amounts are kept for accounting
+type PseudonymizeUserOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PseudonymizeUserOrdersRequest) Reset() {
+	*x = PseudonymizeUserOrdersRequest{}
+	mi := &file_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PseudonymizeUserOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PseudonymizeUserOrdersRequest) ProtoMessage() {}
+
+func (x *PseudonymizeUserOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PseudonymizeUserOrdersRequest.ProtoReflect.Descriptor instead.
+func (*PseudonymizeUserOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PseudonymizeUserOrdersRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type PseudonymizeUserOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pseudonym     uint64                 `protobuf:"varint,1,opt,name=pseudonym,proto3" json:"pseudonym,omitempty"` // replaces user_id on the orders
+	Orders        int64                  `protobuf:"varint,2,opt,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PseudonymizeUserOrdersResponse) Reset() {
+	*x = PseudonymizeUserOrdersResponse{}
+	mi := &file_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PseudonymizeUserOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PseudonymizeUserOrdersResponse) ProtoMessage() {}
+
+func (x *PseudonymizeUserOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PseudonymizeUserOrdersResponse.ProtoReflect.Descriptor instead.
+func (*PseudonymizeUserOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PseudonymizeUserOrdersResponse) GetPseudonym() uint64 {
+	if x != nil {
+		return x.Pseudonym
+	}
+	return 0
+}
+
+func (x *PseudonymizeUserOrdersResponse) GetOrders() int64 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
 var File_order_proto protoreflect.FileDescriptor
 
 const file_order_proto_rawDesc = "" +
 	"\n" +
-	"\vorder.proto\x12\x05order\"[\n" +
+	"\vorder.proto\x12\x05order\"\xb9\x01\n" +
 	"\x12CreateOrderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12,\n" +
-	"\x05items\x18\x02 \x03(\v2\x16.order.CreateOrderItemR\x05items\"L\n" +
+	"\x05items\x18\x02 \x03(\v2\x16.order.CreateOrderItemR\x05items\x12.\n" +
+	"\x13shipping_address_id\x18\x03 \x01(\x04R\x11shippingAddressId\x12,\n" +
+	"\x12billing_address_id\x18\x04 \x01(\x04R\x10billingAddressId\"L\n" +
 	"\x0fCreateOrderItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x1a\n" +
@@ -534,7 +806,7 @@ const file_order_proto_rawDesc = "" +
 	"\auser_id\x18\x02 \x01(\x04R\x06userId\"G\n" +
 	"\x12UpdateOrderRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"\xe4\x01\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x87\x03\n" +
 	"\rOrderResponse\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12&\n" +
@@ -544,20 +816,47 @@ const file_order_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\tR\tupdatedAt\"V\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12>\n" +
+	"\x10shipping_address\x18\b \x01(\v2\x13.order.OrderAddressR\x0fshippingAddress\x12<\n" +
+	"\x0fbilling_address\x18\t \x01(\v2\x13.order.OrderAddressR\x0ebillingAddress\x12#\n" +
+	"\rstatus_reason\x18\n" +
+	" \x01(\tR\fstatusReason\"\xfd\x01\n" +
+	"\fOrderAddress\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x01 \x01(\x04R\taddressId\x12%\n" +
+	"\x0erecipient_name\x18\x02 \x01(\tR\rrecipientName\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x14\n" +
+	"\x05line1\x18\x04 \x01(\tR\x05line1\x12\x14\n" +
+	"\x05line2\x18\x05 \x01(\tR\x05line2\x12\x12\n" +
+	"\x04city\x18\x06 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\a \x01(\tR\x06region\x12\x1f\n" +
+	"\vpostal_code\x18\b \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\t \x01(\tR\acountry\"\x94\x01\n" +
 	"\x11ListOrdersRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x03R\x05limit\"X\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"skip_total\x18\x05 \x01(\bR\tskipTotal\"\x80\x01\n" +
 	"\x12ListOrdersResponse\x12,\n" +
 	"\x06orders\x18\x01 \x03(\v2\x14.order.OrderResponseR\x06orders\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total2\x8b\x02\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"8\n" +
+	"\x1dPseudonymizeUserOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\"V\n" +
+	"\x1ePseudonymizeUserOrdersResponse\x12\x1c\n" +
+	"\tpseudonym\x18\x01 \x01(\x04R\tpseudonym\x12\x16\n" +
+	"\x06orders\x18\x02 \x01(\x03R\x06orders2\xf2\x02\n" +
 	"\fOrderService\x12>\n" +
 	"\vCreateOrder\x12\x19.order.CreateOrderRequest\x1a\x14.order.OrderResponse\x128\n" +
 	"\bGetOrder\x12\x16.order.GetOrderRequest\x1a\x14.order.OrderResponse\x12>\n" +
 	"\vUpdateOrder\x12\x19.order.UpdateOrderRequest\x1a\x14.order.OrderResponse\x12A\n" +
 	"\n" +
-	"ListOrders\x12\x18.order.ListOrdersRequest\x1a\x19.order.ListOrdersResponseB\tZ\a./protob\x06proto3"
+	"ListOrders\x12\x18.order.ListOrdersRequest\x1a\x19.order.ListOrdersResponse\x12e\n" +
+	"\x16PseudonymizeUserOrders\x12$.order.PseudonymizeUserOrdersRequest\x1a%.order.PseudonymizeUserOrdersResponseB\tZ\a./protob\x06proto3"
 
 var (
 	file_order_proto_rawDescOnce sync.Once
@@ -571,34 +870,41 @@ func file_order_proto_rawDescGZIP() []byte {
 	return file_order_proto_rawDescData
 }
 
-var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_order_proto_goTypes = []any{
-	(*CreateOrderRequest)(nil), // 0: order.CreateOrderRequest
-	(*CreateOrderItem)(nil),    // 1: order.CreateOrderItem
-	(*OrderItem)(nil),          // 2: order.OrderItem
-	(*GetOrderRequest)(nil),    // 3: order.GetOrderRequest
-	(*UpdateOrderRequest)(nil), // 4: order.UpdateOrderRequest
-	(*OrderResponse)(nil),      // 5: order.OrderResponse
-	(*ListOrdersRequest)(nil),  // 6: order.ListOrdersRequest
-	(*ListOrdersResponse)(nil), // 7: order.ListOrdersResponse
+	(*CreateOrderRequest)(nil),             // 0: order.CreateOrderRequest
+	(*CreateOrderItem)(nil),                // 1: order.CreateOrderItem
+	(*OrderItem)(nil),                      // 2: order.OrderItem
+	(*GetOrderRequest)(nil),                // 3: order.GetOrderRequest
+	(*UpdateOrderRequest)(nil),             // 4: order.UpdateOrderRequest
+	(*OrderResponse)(nil),                  // 5: order.OrderResponse
+	(*OrderAddress)(nil),                   // 6: order.OrderAddress
+	(*ListOrdersRequest)(nil),              // 7: order.ListOrdersRequest
+	(*ListOrdersResponse)(nil),             // 8: order.ListOrdersResponse
+	(*PseudonymizeUserOrdersRequest)(nil),  // 9: order.PseudonymizeUserOrdersRequest
+	(*PseudonymizeUserOrdersResponse)(nil), // 10: order.PseudonymizeUserOrdersResponse
 }
 var file_order_proto_depIdxs = []int32{
-	1, // 0: order.CreateOrderRequest.items:type_name -> order.CreateOrderItem
-	2, // 1: order.OrderResponse.items:type_name -> order.OrderItem
-	5, // 2: order.ListOrdersResponse.orders:type_name -> order.OrderResponse
-	0, // 3: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
-	3, // 4: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
-	4, // 5: order.OrderService.UpdateOrder:input_type -> order.UpdateOrderRequest
-	6, // 6: order.OrderService.ListOrders:input_type -> order.ListOrdersRequest
-	5, // 7: order.OrderService.CreateOrder:output_type -> order.OrderResponse
-	5, // 8: order.OrderService.GetOrder:output_type -> order.OrderResponse
-	5, // 9: order.OrderService.UpdateOrder:output_type -> order.OrderResponse
-	7, // 10: order.OrderService.ListOrders:output_type -> order.ListOrdersResponse
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	1,  // 0: order.CreateOrderRequest.items:type_name -> order.CreateOrderItem
+	2,  // 1: order.OrderResponse.items:type_name -> order.OrderItem
+	6,  // 2: order.OrderResponse.shipping_address:type_name -> order.OrderAddress
+	6,  // 3: order.OrderResponse.billing_address:type_name -> order.OrderAddress
+	5,  // 4: order.ListOrdersResponse.orders:type_name -> order.OrderResponse
+	0,  // 5: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
+	3,  // 6: order.OrderService.GetOrder:input_type -> order.GetOrderRequest
+	4,  // 7: order.OrderService.UpdateOrder:input_type -> order.UpdateOrderRequest
+	7,  // 8: order.OrderService.ListOrders:input_type -> order.ListOrdersRequest
+	9,  // 9: order.OrderService.PseudonymizeUserOrders:input_type -> order.PseudonymizeUserOrdersRequest
+	5,  // 10: order.OrderService.CreateOrder:output_type -> order.OrderResponse
+	5,  // 11: order.OrderService.GetOrder:output_type -> order.OrderResponse
+	5,  // 12: order.OrderService.UpdateOrder:output_type -> order.OrderResponse
+	8,  // 13: order.OrderService.ListOrders:output_type -> order.ListOrdersResponse
+	10, // 14: order.OrderService.PseudonymizeUserOrders:output_type -> order.PseudonymizeUserOrdersResponse
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_order_proto_init() }
@@ -612,7 +918,7 @@ func file_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_proto_rawDesc), len(file_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,130 @@
+// Command dlq inspects the dead-letter topic of the inventory consumer and replays its messages.
+//
+//	dlq list [-limit 20]
+//	dlq replay -partition 0 -offset 12
+//	dlq replay -all
+//
+// Replayed messages are published back to the topic they failed on. Messages of orders that have been
+// processed in the meantime do not change the stock again, so replaying the same message twice is safe.
+// The command exits with status 1 when it fails, e.g. when a message could not be replayed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-inventory/config"
+	"github.com/BeksultanSE/Assignment1-inventory/internal/adapter/kafka"
+	events "github.com/BeksultanSE/Assignment1-inventory/protos/gen/golang"
+	"google.golang.org/protobuf/proto"
+	"log"
+	"math"
+	"os"
+	"time"
+)
+
+func main() {
+	if len(os.Args) < 2 || (os.Args[1] != "list" && os.Args[1] != "replay") {
+		usage()
+	}
+
+	// run returns before exiting, so the kafka connection is closed either way
+	if err := run(os.Args[1], os.Args[2:]); err != nil {
+		log.Fatalf("error: %v", err)
+	}
+}
+
+func run(command string, args []string) error {
+	cfg, err := config.New()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+
+	dlq, err := kafka.NewDeadLetterQueue(cfg.Brokers, cfg.Consumer.DeadLetterTopic)
+	if err != nil {
+		return fmt.Errorf("error connecting to kafka: %w", err)
+	}
+	defer func() {
+		if err := dlq.Close(); err != nil {
+			log.Printf("error closing kafka connection: %v", err)
+		}
+	}()
+
+	if command == "list" {
+		return list(dlq, args)
+	}
+	return replay(dlq, args)
+}
+
+func list(dlq *kafka.DeadLetterQueue, args []string) error {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	limit := flags.Int("limit", 20, "maximum number of messages to show")
+	_ = flags.Parse(args)
+
+	letters, err := dlq.List(*limit)
+	if err != nil {
+		return err
+	}
+	if len(letters) == 0 {
+		fmt.Println("dead-letter topic is empty")
+		return nil
+	}
+
+	for _, letter := range letters {
+		printLetter(letter)
+	}
+	return nil
+}
+
+func replay(dlq *kafka.DeadLetterQueue, args []string) error {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+	partition := flags.Int("partition", 0, "partition of the message to replay")
+	offset := flags.Int64("offset", -1, "offset of the message to replay")
+	all := flags.Bool("all", false, "replay every message of the dead-letter topic")
+	_ = flags.Parse(args)
+
+	var letters []kafka.DeadLetter
+	switch {
+	case *all:
+		var err error
+		letters, err = dlq.List(math.MaxInt)
+		if err != nil {
+			return err
+		}
+	case *offset >= 0:
+		letter, err := dlq.Get(int32(*partition), *offset)
+		if err != nil {
+			return err
+		}
+		letters = append(letters, letter)
+	default:
+		return fmt.Errorf("either -offset or -all is required")
+	}
+
+	for _, letter := range letters {
+		if err := dlq.Replay(letter); err != nil {
+			return fmt.Errorf("failed to replay partition %d offset %d: %w", letter.Partition, letter.Offset, err)
+		}
+		fmt.Printf("replayed partition %d offset %d to %s\n", letter.Partition, letter.Offset, letter.Header(kafka.HeaderOriginalTopic))
+	}
+	return nil
+}
+
+func printLetter(letter kafka.DeadLetter) {
+	fmt.Printf("partition %d offset %d at %s\n", letter.Partition, letter.Offset, letter.Timestamp.Format(time.RFC3339))
+	fmt.Printf("  from:     %s/%s/%s\n", letter.Header(kafka.HeaderOriginalTopic),
+		letter.Header(kafka.HeaderOriginalPartition), letter.Header(kafka.HeaderOriginalOffset))
+	fmt.Printf("  failed:   %s after %s attempts\n", letter.Header(kafka.HeaderFailedAt), letter.Header(kafka.HeaderAttempts))
+	fmt.Printf("  error:    %s\n", letter.Header(kafka.HeaderError))
+
+	var event events.OrderCreatedEvent
+	if err := proto.Unmarshal(letter.Value, &event); err != nil {
+		fmt.Printf("  payload:  %d bytes, not an OrderCreatedEvent\n", len(letter.Value))
+		return
+	}
+	fmt.Printf("  order:    %d of user %d, %d items\n", event.OrderId, event.UserId, len(event.Items))
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: dlq list [-limit N] | dlq replay (-partition P -offset O | -all)")
+	os.Exit(2)
+}
@@ -11,12 +11,14 @@ import (
 
 type (
 	Config struct {
-		Mongo   mongo.Config
-		Server  Server
-		Redis   Redis
-		Cache   Cache
-		Brokers []string `env:"BROKERS"`
-		Version string   `env:"VERSION"`
+		Mongo       mongo.Config
+		Server      Server
+		Redis       Redis
+		Cache       Cache
+		Reservation Reservation
+		Consumer    Consumer
+		Brokers     []string `env:"BROKERS"`
+		Version     string   `env:"VERSION"`
 	}
 
 	Server struct {
@@ -34,8 +36,9 @@ type (
 	}
 
 	GRPCServer struct {
-		Port    int           `env:"GRPC_PORT,required"`
-		Timeout time.Duration `env:"GRPC_TIMEOUT" envDefault:"30s"`
+		Port    int               `env:"GRPC_PORT,required"`
+		Timeout time.Duration     `env:"GRPC_TIMEOUT" envDefault:"30s"`
+		Callers map[string]string `env:"GRPC_CALLERS,required"` // service name to token of every service allowed to call, e.g. "api-gateway:<token>,order-service:<token>"
 	}
 
 	// Redis configuration for main application
@@ -48,6 +51,21 @@ type (
 		ReadTimeout  time.Duration `env:"REDIS_READ_TIMEOUT" envDefault:"30s"`
 	}
 
+	// Reservation configuration for stock held by orders
+	Reservation struct {
+		HoldTTL       time.Duration `env:"RESERVATION_HOLD_TTL" envDefault:"15m"`
+		MaxHoldTTL    time.Duration `env:"RESERVATION_MAX_HOLD_TTL" envDefault:"1h"`
+		SweepInterval time.Duration `env:"RESERVATION_SWEEP_INTERVAL" envDefault:"30s"`
+	}
+
+	// Consumer configuration for processing order events
+	Consumer struct {
+		MaxRetries      int           `env:"CONSUMER_MAX_RETRIES" envDefault:"3"`
+		RetryBackoff    time.Duration `env:"CONSUMER_RETRY_BACKOFF" envDefault:"500ms"`
+		MaxRetryBackoff time.Duration `env:"CONSUMER_MAX_RETRY_BACKOFF" envDefault:"10s"`
+		DeadLetterTopic string        `env:"CONSUMER_DLQ_TOPIC" envDefault:"order.created.dlq"`
+	}
+
 	//Cache configuration
 	Cache struct {
 		TTL time.Duration `env:"CACHE_TTL" envDefault:"10h"`
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-inventory/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// batchMaxIDs caps the ids of a single batch lookup
+const batchMaxIDs = 500
+
+type BatchGetProductsRequest struct {
+	ProductIDs []uint64
+}
+
+// FromBatchGetRequestProto converts gRPC request to DTO
+func FromBatchGetRequestProto(req *proto.BatchGetProductsRequest) *BatchGetProductsRequest {
+	return &BatchGetProductsRequest{
+		ProductIDs: req.ProductIds,
+	}
+}
+
+// Validate ensures there is something to look up and the batch is not too large
+func (d *BatchGetProductsRequest) Validate() error {
+	if len(d.ProductIDs) == 0 {
+		return status.Error(codes.InvalidArgument, "product_ids are required")
+	}
+	if len(d.ProductIDs) > batchMaxIDs {
+		return status.Errorf(codes.InvalidArgument, "at most %d product_ids can be requested at once", batchMaxIDs)
+	}
+	return nil
+}
+
+// ToProtoBatchGetResponse converts the found products and the missing ids to gRPC response
+func ToProtoBatchGetResponse(products []domain.Product, missing []uint64) *proto.BatchGetProductsResponse {
+	response := &proto.BatchGetProductsResponse{
+		Products:   make([]*proto.ProductResponse, len(products)),
+		MissingIds: missing,
+	}
+	for i, product := range products {
+		response.Products[i] = FromProduct(product).ToProtoProductResponse()
+	}
+	return response
+}
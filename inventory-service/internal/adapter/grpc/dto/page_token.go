@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	"time"
+)
+
+// productPageToken is the cursor handed out to clients, it is opaque to them and only read back here
+type productPageToken struct {
+	SortBy     domain.ProductSortField `json:"s,omitempty"`
+	Descending bool                    `json:"d,omitempty"`
+	ID         uint64                  `json:"i"`
+	Price      float64                 `json:"p,omitempty"`
+	Name       string                  `json:"n,omitempty"`
+	CreatedAt  *time.Time              `json:"c,omitempty"`
+	Stock      uint64                  `json:"q,omitempty"`
+}
+
+// EncodeProductPageToken turns the cursor into the next_page_token of a response, empty for the last page
+func EncodeProductPageToken(cursor *domain.ProductCursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	token := productPageToken{
+		SortBy:     cursor.Sort.Field,
+		Descending: cursor.Sort.Descending,
+		ID:         cursor.ID,
+		Price:      cursor.Price,
+		Name:       cursor.Name,
+		Stock:      cursor.Stock,
+	}
+	if cursor.Sort.Field == domain.ProductSortCreatedAt {
+		token.CreatedAt = &cursor.CreatedAt
+	}
+
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeProductPageToken(value string) (domain.ProductCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return domain.ProductCursor{}, err
+	}
+
+	var token productPageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return domain.ProductCursor{}, err
+	}
+
+	cursor := domain.ProductCursor{
+		Sort:  domain.ProductSort{Field: token.SortBy, Descending: token.Descending},
+		ID:    token.ID,
+		Price: token.Price,
+		Name:  token.Name,
+		Stock: token.Stock,
+	}
+	if token.CreatedAt != nil {
+		cursor.CreatedAt = *token.CreatedAt
+	}
+	return cursor, nil
+}
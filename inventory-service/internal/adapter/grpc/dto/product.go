@@ -3,24 +3,33 @@ package dto
 import (
 	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
 	proto "github.com/BeksultanSE/Assignment1-inventory/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
 	"time"
 )
 
 type CreateProductRequest struct {
-	Name     string
-	Category string
-	Price    float64
-	Stock    uint64
+	Name        string
+	Category    string
+	Description string
+	Price       float64
+	Stock       uint64
+	Specs       *domain.MotorcycleSpecs
 }
 
 type ProductResponse struct {
-	ID        uint64
-	Name      string
-	Category  string
-	Price     float64
-	Stock     uint64
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          uint64
+	Name        string
+	Category    string
+	Description string
+	Price       float64
+	Stock       uint64
+	Reserved    uint64
+	Available   uint64
+	Specs       *domain.MotorcycleSpecs
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 type GetProductRequest struct {
@@ -28,20 +37,38 @@ type GetProductRequest struct {
 }
 
 type UpdateProductRequest struct {
-	ProductID uint64
-	Name      *string
-	Category  *string
-	Price     *float64
-	Stock     *uint64
+	ProductID   uint64
+	Name        *string
+	Category    *string
+	Description *string
+	Price       *float64
+	Stock       *uint64
+	Specs       *domain.MotorcycleSpecs
 }
 
 type ListProductsRequest struct {
-	Name     *string
-	Category *string
-	Price    *float64
-	Stock    *uint64
-	Page     int64
-	Limit    int64
+	Name        *string
+	Category    *string
+	Categories  []string
+	Price       *float64
+	PriceMin    *float64
+	PriceMax    *float64
+	Stock       *uint64
+	InStockOnly bool
+	SortBy      string
+	SortOrder   string
+	Page        int64
+	Limit       int64
+	PageToken   string
+	SkipTotal   bool
+
+	Brand          *string
+	EngineCCMin    *uint32
+	EngineCCMax    *uint32
+	ModelYearMin   *uint32
+	ModelYearMax   *uint32
+	LicenseClasses []domain.LicenseClass
+	Transmission   *domain.Transmission
 }
 
 type DeleteProductRequest struct {
@@ -51,46 +78,63 @@ type DeleteProductRequest struct {
 // FromCreateRequestProto converts gRPC request to DTO
 func FromCreateRequestProto(req *proto.CreateProductRequest) *CreateProductRequest {
 	return &CreateProductRequest{
-		Name:     req.Name,
-		Category: req.Category,
-		Price:    req.Price,
-		Stock:    req.Stock,
+		Name:        req.Name,
+		Category:    req.Category,
+		Description: req.Description,
+		Price:       req.Price,
+		Stock:       req.Stock,
+		Specs:       fromProtoSpecs(req.Specs),
 	}
 }
 
+// Validate ensures the specs of a motorcycle are plausible
+func (d *CreateProductRequest) Validate() error {
+	return validateSpecs(d.Specs)
+}
+
 // ToProduct converts DTO to domain model
 func (d *CreateProductRequest) ToProduct() domain.Product {
 	return domain.Product{
-		Name:     d.Name,
-		Category: d.Category,
-		Price:    d.Price,
-		Stock:    d.Stock,
+		Name:        d.Name,
+		Category:    d.Category,
+		Description: d.Description,
+		Price:       d.Price,
+		Stock:       d.Stock,
+		Specs:       d.Specs,
 	}
 }
 
 // FromProduct converts domain model to DTO
 func FromProduct(product domain.Product) *ProductResponse {
 	return &ProductResponse{
-		ID:        product.ID,
-		Name:      product.Name,
-		Category:  product.Category,
-		Price:     product.Price,
-		Stock:     product.Stock,
-		CreatedAt: product.CreatedAt,
-		UpdatedAt: product.UpdatedAt,
+		ID:          product.ID,
+		Name:        product.Name,
+		Category:    product.Category,
+		Description: product.Description,
+		Price:       product.Price,
+		Stock:       product.Stock,
+		Reserved:    product.Reserved,
+		Available:   product.Available(),
+		Specs:       product.Specs,
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
 	}
 }
 
 // ToProtoProductResponse converts DTO to gRPC response
 func (d *ProductResponse) ToProtoProductResponse() *proto.ProductResponse {
 	return &proto.ProductResponse{
-		ProductId: d.ID,
-		Name:      d.Name,
-		Category:  d.Category,
-		Price:     d.Price,
-		Stock:     d.Stock,
-		CreatedAt: d.CreatedAt.String(),
-		UpdatedAt: d.UpdatedAt.String(),
+		ProductId:   d.ID,
+		Name:        d.Name,
+		Category:    d.Category,
+		Description: d.Description,
+		Price:       d.Price,
+		Stock:       d.Stock,
+		Reserved:    d.Reserved,
+		Available:   d.Available,
+		Specs:       toProtoSpecs(d.Specs),
+		CreatedAt:   d.CreatedAt.String(),
+		UpdatedAt:   d.UpdatedAt.String(),
 	}
 }
 
@@ -111,47 +155,160 @@ func (d *GetProductRequest) ToDomainFilter() domain.ProductFilter {
 // FromUpdateRequestProto converts gRPC request to DTO
 func FromUpdateRequestProto(req *proto.UpdateProductRequest) *UpdateProductRequest {
 	return &UpdateProductRequest{
-		ProductID: req.ProductId,
-		Name:      req.Name,
-		Category:  req.Category,
-		Price:     req.Price,
-		Stock:     req.Stock,
+		ProductID:   req.ProductId,
+		Name:        req.Name,
+		Category:    req.Category,
+		Description: req.Description,
+		Price:       req.Price,
+		Stock:       req.Stock,
+		Specs:       fromProtoSpecs(req.Specs),
 	}
 }
 
+// Validate ensures the new specs of a motorcycle are plausible
+func (d *UpdateProductRequest) Validate() error {
+	return validateSpecs(d.Specs)
+}
+
 // ToDomainFilterAndUpdate converts DTO to domain filter and update data
 func (d *UpdateProductRequest) ToDomainFilterAndUpdate() (domain.ProductFilter, domain.ProductUpdateData) {
 	filter := domain.ProductFilter{
 		ID: &d.ProductID,
 	}
 	update := domain.ProductUpdateData{
-		Name:     d.Name,
-		Category: d.Category,
-		Price:    d.Price,
-		Stock:    d.Stock,
+		Name:        d.Name,
+		Category:    d.Category,
+		Description: d.Description,
+		Price:       d.Price,
+		Stock:       d.Stock,
+		Specs:       d.Specs,
 	}
 	return filter, update
 }
 
 // FromListRequestProto converts gRPC request to DTO
 func FromListRequestProto(req *proto.ListProductsRequest) *ListProductsRequest {
-	return &ListProductsRequest{
-		Name:     req.Name,
-		Category: req.Category,
-		Price:    req.Price,
-		Stock:    req.Stock,
-		Page:     req.Page,
-		Limit:    req.Limit,
+	d := &ListProductsRequest{
+		Name:        req.Name,
+		Category:    req.Category,
+		Categories:  req.Categories,
+		Price:       req.Price,
+		PriceMin:    req.PriceMin,
+		PriceMax:    req.PriceMax,
+		Stock:       req.Stock,
+		InStockOnly: req.InStockOnly,
+		SortBy:      req.SortBy,
+		SortOrder:   req.SortOrder,
+		Page:        req.Page,
+		Limit:       req.Limit,
+		PageToken:   req.PageToken,
+		SkipTotal:   req.SkipTotal,
+
+		Brand:        req.Brand,
+		EngineCCMin:  req.EngineCcMin,
+		EngineCCMax:  req.EngineCcMax,
+		ModelYearMin: req.ModelYearMin,
+		ModelYearMax: req.ModelYearMax,
+	}
+	for _, class := range req.LicenseClasses {
+		d.LicenseClasses = append(d.LicenseClasses, domain.LicenseClass(strings.ToUpper(strings.TrimSpace(class))))
+	}
+	if req.Transmission != nil {
+		transmission := domain.Transmission(strings.TrimSpace(*req.Transmission))
+		d.Transmission = &transmission
+	}
+	return d
+}
+
+// Validate ensures the price, engine and model year ranges, the spec values and the sort are usable
+func (d *ListProductsRequest) Validate() error {
+	if d.PriceMin != nil && *d.PriceMin < 0 {
+		return status.Error(codes.InvalidArgument, "price_min must not be negative")
+	}
+	if d.PriceMax != nil && *d.PriceMax < 0 {
+		return status.Error(codes.InvalidArgument, "price_max must not be negative")
+	}
+	if d.PriceMin != nil && d.PriceMax != nil && *d.PriceMin > *d.PriceMax {
+		return status.Error(codes.InvalidArgument, "price_min must not be greater than price_max")
+	}
+
+	if d.EngineCCMin != nil && d.EngineCCMax != nil && *d.EngineCCMin > *d.EngineCCMax {
+		return status.Error(codes.InvalidArgument, "engine_cc_min must not be greater than engine_cc_max")
 	}
+	if d.ModelYearMin != nil && d.ModelYearMax != nil && *d.ModelYearMin > *d.ModelYearMax {
+		return status.Error(codes.InvalidArgument, "model_year_min must not be greater than model_year_max")
+	}
+	for _, class := range d.LicenseClasses {
+		if err := validateLicenseClass("license_classes", class); err != nil {
+			return err
+		}
+	}
+	if d.Transmission != nil {
+		if err := validateTransmission("transmission", *d.Transmission); err != nil {
+			return err
+		}
+	}
+
+	switch domain.ProductSortField(d.SortBy) {
+	case "", domain.ProductSortPrice, domain.ProductSortName, domain.ProductSortCreatedAt, domain.ProductSortStock:
+	default:
+		return status.Error(codes.InvalidArgument, "sort_by must be one of price, name, created_at, stock")
+	}
+
+	switch d.SortOrder {
+	case "", "asc", "desc":
+	default:
+		return status.Error(codes.InvalidArgument, "sort_order must be asc or desc")
+	}
+	return nil
 }
 
 // ToDomainFilter converts DTO to domain filter
 func (d *ListProductsRequest) ToDomainFilter() domain.ProductFilter {
 	return domain.ProductFilter{
-		Name:     d.Name,
-		Category: d.Category,
-		Price:    d.Price,
-		Stock:    d.Stock,
+		Name:        d.Name,
+		Category:    d.Category,
+		Categories:  d.Categories,
+		Price:       d.Price,
+		PriceMin:    d.PriceMin,
+		PriceMax:    d.PriceMax,
+		Stock:       d.Stock,
+		InStockOnly: d.InStockOnly,
+
+		Brand:          d.Brand,
+		EngineCCMin:    d.EngineCCMin,
+		EngineCCMax:    d.EngineCCMax,
+		ModelYearMin:   d.ModelYearMin,
+		ModelYearMax:   d.ModelYearMax,
+		LicenseClasses: d.LicenseClasses,
+		Transmission:   d.Transmission,
+	}
+}
+
+// ToDomainPage converts DTO to the page to fetch. A page token only continues the listing it was issued
+// for, it is rejected when the sort has changed since.
+func (d *ListProductsRequest) ToDomainPage() (domain.ProductPage, error) {
+	page := domain.ProductPage{Page: d.Page, Limit: d.Limit, SkipTotal: d.SkipTotal}
+	if d.PageToken == "" {
+		return page, nil
+	}
+
+	cursor, err := decodeProductPageToken(d.PageToken)
+	if err != nil {
+		return domain.ProductPage{}, status.Error(codes.InvalidArgument, "page_token is malformed")
+	}
+	if cursor.Sort != d.ToDomainSort() {
+		return domain.ProductPage{}, status.Error(codes.InvalidArgument, "page_token was issued for another sort")
+	}
+	page.After = &cursor
+	return page, nil
+}
+
+// ToDomainSort converts DTO to domain sort
+func (d *ListProductsRequest) ToDomainSort() domain.ProductSort {
+	return domain.ProductSort{
+		Field:      domain.ProductSortField(d.SortBy),
+		Descending: d.SortOrder == "desc",
 	}
 }
 
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-inventory/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"unicode"
+)
+
+const (
+	searchDefaultLimit   = 10
+	searchMaxLimit       = 100
+	searchMaxPage        = 1000
+	searchMaxQueryLength = 200
+)
+
+type SearchProductsRequest struct {
+	Query string
+	Page  int64
+	Limit int64
+}
+
+// FromSearchRequestProto converts gRPC request to DTO, missing pagination falls back to the first page
+func FromSearchRequestProto(req *proto.SearchProductsRequest) *SearchProductsRequest {
+	d := &SearchProductsRequest{
+		Query: strings.TrimSpace(req.Query),
+		Page:  req.Page,
+		Limit: req.Limit,
+	}
+	if d.Page < 1 {
+		d.Page = 1
+	}
+	if d.Limit < 1 {
+		d.Limit = searchDefaultLimit
+	}
+	if d.Limit > searchMaxLimit {
+		d.Limit = searchMaxLimit
+	}
+	return d
+}
+
+// Validate ensures the query contains something to search for and the page can be reached
+func (d *SearchProductsRequest) Validate() error {
+	if !strings.ContainsFunc(d.Query, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) {
+		return status.Error(codes.InvalidArgument, "query is required")
+	}
+	if len(d.Query) > searchMaxQueryLength {
+		return status.Errorf(codes.InvalidArgument, "query must not be longer than %d characters", searchMaxQueryLength)
+	}
+	if d.Page > searchMaxPage {
+		return status.Errorf(codes.InvalidArgument, "page must not be greater than %d", searchMaxPage)
+	}
+	return nil
+}
+
+// ToProtoSearchResponse converts the search result to gRPC response
+func ToProtoSearchResponse(result domain.ProductSearch) *proto.SearchProductsResponse {
+	response := &proto.SearchProductsResponse{
+		Products:    make([]*proto.ProductResponse, len(result.Products)),
+		Total:       int64(result.Total),
+		Approximate: result.Approximate,
+	}
+	for i, product := range result.Products {
+		response.Products[i] = FromProduct(product).ToProtoProductResponse()
+	}
+	return response
+}
@@ -0,0 +1,156 @@
+package dto
+
+import (
+	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-inventory/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"time"
+)
+
+// Limits of the motorcycle specs, values beyond them are typos rather than motorcycles
+const (
+	specsFirstModelYear  = 1885
+	specsMaxEngineCC     = 3000
+	specsMaxPowerKW      = 300
+	specsMaxWeightKG     = 1000
+	specsMaxSeatHeightMM = 1500
+	specsMaxFuelL        = 100
+	specsMaxColorways    = 20
+	specsMaxTextLength   = 100
+
+	// power limits of the restricted license classes
+	licenseA1MaxEngineCC = 125
+	licenseA1MaxPowerKW  = 11
+	licenseA2MaxPowerKW  = 35
+)
+
+// fromProtoSpecs converts gRPC specs to domain specs, text is trimmed and empty colorways are dropped
+func fromProtoSpecs(specs *proto.MotorcycleSpecs) *domain.MotorcycleSpecs {
+	if specs == nil {
+		return nil
+	}
+
+	var colorways []string
+	for _, colorway := range specs.Colorways {
+		if colorway = strings.TrimSpace(colorway); colorway != "" {
+			colorways = append(colorways, colorway)
+		}
+	}
+
+	return &domain.MotorcycleSpecs{
+		Brand:         strings.TrimSpace(specs.Brand),
+		Model:         strings.TrimSpace(specs.Model),
+		ModelYear:     specs.ModelYear,
+		EngineCC:      specs.EngineCc,
+		PowerKW:       specs.PowerKw,
+		WeightKG:      specs.WeightKg,
+		SeatHeightMM:  specs.SeatHeightMm,
+		FuelCapacityL: specs.FuelCapacityL,
+		Transmission:  domain.Transmission(strings.TrimSpace(specs.Transmission)),
+		LicenseClass:  domain.LicenseClass(strings.ToUpper(strings.TrimSpace(specs.LicenseClass))),
+		Colorways:     colorways,
+	}
+}
+
+// toProtoSpecs converts domain specs to gRPC specs
+func toProtoSpecs(specs *domain.MotorcycleSpecs) *proto.MotorcycleSpecs {
+	if specs == nil {
+		return nil
+	}
+	return &proto.MotorcycleSpecs{
+		Brand:         specs.Brand,
+		Model:         specs.Model,
+		ModelYear:     specs.ModelYear,
+		EngineCc:      specs.EngineCC,
+		PowerKw:       specs.PowerKW,
+		WeightKg:      specs.WeightKG,
+		SeatHeightMm:  specs.SeatHeightMM,
+		FuelCapacityL: specs.FuelCapacityL,
+		Transmission:  string(specs.Transmission),
+		LicenseClass:  string(specs.LicenseClass),
+		Colorways:     specs.Colorways,
+	}
+}
+
+// validateSpecs ensures the specs describe a real motorcycle and fit the license class they are sold for
+func validateSpecs(specs *domain.MotorcycleSpecs) error {
+	if specs == nil {
+		return nil
+	}
+
+	if specs.Brand == "" || specs.Model == "" {
+		return status.Error(codes.InvalidArgument, "specs.brand and specs.model are required")
+	}
+	if len(specs.Brand) > specsMaxTextLength || len(specs.Model) > specsMaxTextLength {
+		return status.Errorf(codes.InvalidArgument, "specs.brand and specs.model must not be longer than %d characters", specsMaxTextLength)
+	}
+	if specs.ModelYear != 0 && (specs.ModelYear < specsFirstModelYear || int(specs.ModelYear) > time.Now().Year()+1) {
+		return status.Errorf(codes.InvalidArgument, "specs.model_year must be between %d and next year", specsFirstModelYear)
+	}
+	if specs.EngineCC > specsMaxEngineCC {
+		return status.Errorf(codes.InvalidArgument, "specs.engine_cc must not be greater than %d", specsMaxEngineCC)
+	}
+	if specs.PowerKW < 0 || specs.PowerKW > specsMaxPowerKW {
+		return status.Errorf(codes.InvalidArgument, "specs.power_kw must be between 0 and %d", specsMaxPowerKW)
+	}
+	if specs.WeightKG < 0 || specs.WeightKG > specsMaxWeightKG {
+		return status.Errorf(codes.InvalidArgument, "specs.weight_kg must be between 0 and %d", specsMaxWeightKG)
+	}
+	if specs.SeatHeightMM > specsMaxSeatHeightMM {
+		return status.Errorf(codes.InvalidArgument, "specs.seat_height_mm must not be greater than %d", specsMaxSeatHeightMM)
+	}
+	if specs.FuelCapacityL < 0 || specs.FuelCapacityL > specsMaxFuelL {
+		return status.Errorf(codes.InvalidArgument, "specs.fuel_capacity_l must be between 0 and %d", specsMaxFuelL)
+	}
+
+	if specs.Transmission != "" {
+		if err := validateTransmission("specs.transmission", specs.Transmission); err != nil {
+			return err
+		}
+	}
+	if specs.LicenseClass != "" {
+		if err := validateLicenseClass("specs.license_class", specs.LicenseClass); err != nil {
+			return err
+		}
+	}
+	switch specs.LicenseClass {
+	case domain.LicenseA1:
+		if specs.EngineCC > licenseA1MaxEngineCC || specs.PowerKW > licenseA1MaxPowerKW {
+			return status.Errorf(codes.InvalidArgument, "license class A1 allows at most %d cc and %d kW", licenseA1MaxEngineCC, licenseA1MaxPowerKW)
+		}
+	case domain.LicenseA2:
+		if specs.PowerKW > licenseA2MaxPowerKW {
+			return status.Errorf(codes.InvalidArgument, "license class A2 allows at most %d kW", licenseA2MaxPowerKW)
+		}
+	}
+
+	if len(specs.Colorways) > specsMaxColorways {
+		return status.Errorf(codes.InvalidArgument, "at most %d specs.colorways are allowed", specsMaxColorways)
+	}
+	for _, colorway := range specs.Colorways {
+		if len(colorway) > specsMaxTextLength {
+			return status.Errorf(codes.InvalidArgument, "specs.colorways must not be longer than %d characters", specsMaxTextLength)
+		}
+	}
+	return nil
+}
+
+// validateTransmission accepts the known transmissions
+func validateTransmission(field string, transmission domain.Transmission) error {
+	switch transmission {
+	case domain.TransmissionManual, domain.TransmissionAutomatic, domain.TransmissionSemiAutomatic:
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "%s must be one of manual, automatic, semi_automatic", field)
+}
+
+// validateLicenseClass accepts the known license classes
+func validateLicenseClass(field string, class domain.LicenseClass) error {
+	switch class {
+	case domain.LicenseA1, domain.LicenseA2, domain.LicenseA:
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "%s must be one of A1, A2, A", field)
+}
@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-inventory/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+type StockRequest struct {
+	OrderID uint64
+	Items   []domain.StockItem
+	Hold    time.Duration
+}
+
+// FromStockRequestProto converts gRPC request to DTO
+func FromStockRequestProto(req *proto.StockRequest) *StockRequest {
+	items := make([]domain.StockItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, domain.StockItem{
+			ProductID: item.ProductId,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return &StockRequest{
+		OrderID: req.OrderId,
+		Items:   items,
+		Hold:    time.Duration(req.HoldSeconds) * time.Second,
+	}
+}
+
+// Validate ensures the request names the order, at least one item and every quantity is positive and in range
+func (d *StockRequest) Validate() error {
+	if err := d.ValidateOrder(); err != nil {
+		return err
+	}
+	if len(d.Items) == 0 {
+		return status.Error(codes.InvalidArgument, "items are required")
+	}
+	for i, item := range d.Items {
+		if item.ProductID == 0 {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("items[%d].product_id is required", i))
+		}
+		if item.Quantity == 0 {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("items[%d].quantity must be positive", i))
+		}
+		if item.Quantity > domain.MaxStockQuantity {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("items[%d].quantity must not be greater than %d", i, domain.MaxStockQuantity))
+		}
+	}
+	return nil
+}
+
+// ValidateOrder ensures the request names the order whose hold is released or committed
+func (d *StockRequest) ValidateOrder() error {
+	if d.OrderID == 0 {
+		return status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	return nil
+}
+
+// ToProtoReserveResponse converts the created hold to gRPC response
+func ToProtoReserveResponse(reservation domain.Reservation) *proto.StockResponse {
+	return &proto.StockResponse{
+		Message:   "Stock reserved successfully",
+		ExpiresAt: reservation.ExpiresAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -13,15 +13,22 @@ import (
 
 type InventoryGRPCServer struct {
 	proto.UnimplementedInventoryServiceServer
-	productUsecase *usecase.Product
+	productUsecase     *usecase.Product
+	reservationUsecase *usecase.Reservation
 }
 
-func NewInventoryGRPCServer(productUsecase *usecase.Product) *InventoryGRPCServer {
-	return &InventoryGRPCServer{productUsecase: productUsecase}
+func NewInventoryGRPCServer(productUsecase *usecase.Product, reservationUsecase *usecase.Reservation) *InventoryGRPCServer {
+	return &InventoryGRPCServer{
+		productUsecase:     productUsecase,
+		reservationUsecase: reservationUsecase,
+	}
 }
 
 func (s *InventoryGRPCServer) CreateProduct(ctx context.Context, req *proto.CreateProductRequest) (*proto.ProductResponse, error) {
 	requestDTO := dto.FromCreateRequestProto(req)
+	if err := requestDTO.Validate(); err != nil {
+		return nil, err
+	}
 	domainProduct := requestDTO.ToProduct()
 
 	createdProduct, err := s.productUsecase.Create(ctx, domainProduct)
@@ -49,8 +56,25 @@ func (s *InventoryGRPCServer) GetProduct(ctx context.Context, req *proto.GetProd
 	return responseDTO.ToProtoProductResponse(), nil
 }
 
+func (s *InventoryGRPCServer) BatchGetProducts(ctx context.Context, req *proto.BatchGetProductsRequest) (*proto.BatchGetProductsResponse, error) {
+	requestDTO := dto.FromBatchGetRequestProto(req)
+	if err := requestDTO.Validate(); err != nil {
+		return nil, err
+	}
+
+	products, missing, err := s.productUsecase.GetMany(ctx, requestDTO.ProductIDs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return dto.ToProtoBatchGetResponse(products, missing), nil
+}
+
 func (s *InventoryGRPCServer) UpdateProduct(ctx context.Context, req *proto.UpdateProductRequest) (*proto.ProductResponse, error) {
 	requestDTO := dto.FromUpdateRequestProto(req)
+	if err := requestDTO.Validate(); err != nil {
+		return nil, err
+	}
 	filter, update := requestDTO.ToDomainFilterAndUpdate()
 
 	err := s.productUsecase.Update(ctx, filter, update)
@@ -69,18 +93,26 @@ func (s *InventoryGRPCServer) UpdateProduct(ctx context.Context, req *proto.Upda
 
 func (s *InventoryGRPCServer) ListProducts(ctx context.Context, req *proto.ListProductsRequest) (*proto.ListProductsResponse, error) {
 	requestDTO := dto.FromListRequestProto(req)
+	if err := requestDTO.Validate(); err != nil {
+		return nil, err
+	}
 	filter := requestDTO.ToDomainFilter()
+	page, err := requestDTO.ToDomainPage()
+	if err != nil {
+		return nil, err
+	}
 
-	products, total, err := s.productUsecase.GetAll(ctx, filter, requestDTO.Page, requestDTO.Limit)
+	list, err := s.productUsecase.GetAll(ctx, filter, requestDTO.ToDomainSort(), page)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	response := &proto.ListProductsResponse{
-		Products: make([]*proto.ProductResponse, len(products)),
-		Total:    int64(total),
+		Products:      make([]*proto.ProductResponse, len(list.Products)),
+		Total:         int64(list.Total),
+		NextPageToken: dto.EncodeProductPageToken(list.Next),
 	}
-	for i, prod := range products {
+	for i, prod := range list.Products {
 		responseDTO := dto.FromProduct(prod)
 		response.Products[i] = responseDTO.ToProtoProductResponse()
 	}
@@ -99,3 +131,88 @@ func (s *InventoryGRPCServer) DeleteProduct(ctx context.Context, req *proto.Dele
 
 	return &proto.DeleteProductResponse{Message: "Product deleted successfully"}, nil
 }
+
+func (s *InventoryGRPCServer) SearchProducts(ctx context.Context, req *proto.SearchProductsRequest) (*proto.SearchProductsResponse, error) {
+	requestDTO := dto.FromSearchRequestProto(req)
+	if err := requestDTO.Validate(); err != nil {
+		return nil, err
+	}
+
+	result, err := s.productUsecase.Search(ctx, requestDTO.Query, requestDTO.Page, requestDTO.Limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return dto.ToProtoSearchResponse(result), nil
+}
+
+func (s *InventoryGRPCServer) ReserveStock(ctx context.Context, req *proto.StockRequest) (*proto.StockResponse, error) {
+	requestDTO := dto.FromStockRequestProto(req)
+	if err := requestDTO.Validate(); err != nil {
+		return nil, err
+	}
+
+	reservation, err := s.reservationUsecase.Reserve(ctx, requestDTO.OrderID, requestDTO.Items, requestDTO.Hold)
+	if err != nil {
+		return nil, stockStatusError(err)
+	}
+
+	return dto.ToProtoReserveResponse(reservation), nil
+}
+
+func (s *InventoryGRPCServer) ReleaseStock(ctx context.Context, req *proto.StockRequest) (*proto.StockResponse, error) {
+	requestDTO := dto.FromStockRequestProto(req)
+	if err := requestDTO.ValidateOrder(); err != nil {
+		return nil, err
+	}
+
+	if err := s.reservationUsecase.Release(ctx, requestDTO.OrderID); err != nil {
+		return nil, stockStatusError(err)
+	}
+
+	return &proto.StockResponse{Message: "Stock released successfully"}, nil
+}
+
+func (s *InventoryGRPCServer) CommitStock(ctx context.Context, req *proto.StockRequest) (*proto.StockResponse, error) {
+	requestDTO := dto.FromStockRequestProto(req)
+	if err := requestDTO.ValidateOrder(); err != nil {
+		return nil, err
+	}
+
+	if err := s.reservationUsecase.Commit(ctx, requestDTO.OrderID); err != nil {
+		return nil, stockStatusError(err)
+	}
+
+	return &proto.StockResponse{Message: "Stock committed successfully"}, nil
+}
+
+func (s *InventoryGRPCServer) RestockOrder(ctx context.Context, req *proto.StockRequest) (*proto.StockResponse, error) {
+	requestDTO := dto.FromStockRequestProto(req)
+	if err := requestDTO.ValidateOrder(); err != nil {
+		return nil, err
+	}
+
+	restocked, err := s.productUsecase.RestockOrder(ctx, requestDTO.OrderID)
+	if err != nil {
+		return nil, stockStatusError(err)
+	}
+	if !restocked {
+		return &proto.StockResponse{Message: "No deducted stock to return"}, nil
+	}
+
+	return &proto.StockResponse{Message: "Stock returned successfully"}, nil
+}
+
+// stockStatusError maps failed stock changes, nothing has been changed when one of them is returned
+func stockStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInsufficientStock), errors.Is(err, domain.ErrReservationExpired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrReservationExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrProductNotFound), errors.Is(err, domain.ErrReservationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
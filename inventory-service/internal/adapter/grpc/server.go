@@ -25,10 +25,10 @@ type ServerAPI struct {
 	inventoryHandler *InventoryGRPCServer
 }
 
-func New(cfg config.Server, productUsecase *usecase.Product) *ServerAPI {
-	grpcServer := grpc.NewServer()
+func New(cfg config.Server, productUsecase *usecase.Product, reservationUsecase *usecase.Reservation) *ServerAPI {
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(authenticate(cfg.GRPCServer.Callers), authorize))
 
-	inventoryHandler := NewInventoryGRPCServer(productUsecase)
+	inventoryHandler := NewInventoryGRPCServer(productUsecase, reservationUsecase)
 	proto.RegisterInventoryServiceServer(grpcServer, inventoryHandler)
 
 	server := &ServerAPI{
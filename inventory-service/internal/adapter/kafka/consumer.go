@@ -1,21 +1,37 @@
 package kafka
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
 	"github.com/BeksultanSE/Assignment1-inventory/internal/usecase"
 	events "github.com/BeksultanSE/Assignment1-inventory/protos/gen/golang"
 	"github.com/IBM/sarama"
 	"google.golang.org/protobuf/proto"
 	"log"
+	"time"
 )
 
+// RetryPolicy tells how often a failed message is retried before it is dead-lettered. The backoff doubles
+// after every attempt up to MaxBackoff.
+type RetryPolicy struct {
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Consumer deducts the stock of created orders and replies with inventory.reserved or inventory.rejected
 type Consumer struct {
-	usecase *usecase.Product
-	Topic   string
+	usecase   *usecase.Product
+	Topic     string
+	retry     RetryPolicy
+	dlq       *DeadLetterProducer
+	publisher *Producer
 }
 
-func NewConsumer(usecase *usecase.Product, topic string) *Consumer {
-	return &Consumer{usecase: usecase, Topic: topic}
+func NewConsumer(usecase *usecase.Product, topic string, retry RetryPolicy, dlq *DeadLetterProducer, publisher *Producer) *Consumer {
+	return &Consumer{usecase: usecase, Topic: topic, retry: retry, dlq: dlq, publisher: publisher}
 }
 
 func (h *Consumer) Setup(sarama.ConsumerGroupSession) error {
@@ -26,32 +42,74 @@ func (h *Consumer) Cleanup(sarama.ConsumerGroupSession) error {
 	return nil
 }
 
+// ConsumeClaim marks a message only once it has been processed or dead-lettered, so no stock change is lost.
+// Messages left unmarked on shutdown or rebalance are delivered again.
 func (h *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	for message := range claim.Messages() {
-		var event events.OrderCreatedEvent
-		if err := proto.Unmarshal(message.Value, &event); err != nil {
-			log.Printf("Failed to unmarshal OrderCreatedEvent: %v", err)
-			continue
-		}
-
-		for _, item := range event.Items {
-			filter := domain.ProductFilter{ID: &item.ProductId}
-			//updating the stock
-			currentProduct, err := h.usecase.Get(session.Context(), filter)
-			if err != nil {
-				log.Printf("Failed to get product from consumer: %v", err)
+		attempts, err := h.process(session.Context(), message)
+		if err != nil {
+			if session.Context().Err() != nil {
+				return nil
 			}
-			newStock := currentProduct.Stock - item.Quantity
 
-			update := domain.ProductUpdateData{
-				Stock: &newStock,
-			}
-			log.Printf("ProductUpdateData: %v", newStock)
-			if err := h.usecase.Update(session.Context(), filter, update); err != nil {
-				log.Printf("Failed to update stock for product %d: %v", item.ProductId, err)
+			if errDLQ := h.dlq.Send(message, err, attempts); errDLQ != nil {
+				return fmt.Errorf("failed to dead-letter message at partition %d offset %d: %w", message.Partition, message.Offset, errDLQ)
 			}
+			log.Printf("Moved message at partition %d offset %d to dead-letter topic after %d attempts: %v", message.Partition, message.Offset, attempts, err)
 		}
 		session.MarkMessage(message, "")
 	}
 	return nil
 }
+
+// process handles the message, retrying with backoff while it fails. It returns the number of attempts made.
+// Messages that cannot be decoded are not retried as they would never succeed.
+func (h *Consumer) process(ctx context.Context, message *sarama.ConsumerMessage) (int, error) {
+	var event events.OrderCreatedEvent
+	if err := proto.Unmarshal(message.Value, &event); err != nil {
+		return 1, fmt.Errorf("failed to unmarshal OrderCreatedEvent: %w", err)
+	}
+
+	backoff := h.retry.Backoff
+	for attempt := 1; ; attempt++ {
+		err := h.handle(ctx, &event)
+		if err == nil || attempt > h.retry.MaxRetries {
+			return attempt, err
+		}
+
+		log.Printf("Failed to process order %d (attempt %d), retrying in %s: %v", event.OrderId, attempt, backoff, err)
+		select {
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, h.retry.MaxBackoff)
+	}
+}
+
+func (h *Consumer) handle(ctx context.Context, event *events.OrderCreatedEvent) error {
+	items := make([]domain.StockItem, 0, len(event.Items))
+	for _, item := range event.Items {
+		items = append(items, domain.StockItem{ProductID: item.ProductId, Quantity: item.Quantity})
+	}
+
+	// the whole order is deducted atomically per product, an order that does not fit the stock changes nothing.
+	// Redelivered events of an order that has been processed already do not change the stock again, their
+	// outcome is published once more in case it got lost the first time.
+	processed, err := h.usecase.DeductOrderStock(ctx, event.OrderId, items)
+	if errors.Is(err, domain.ErrOrderAlreadyProcessed) {
+		log.Printf("Order %d has already been processed, publishing its outcome again", event.OrderId)
+		processed, err = h.usecase.GetProcessedOrder(ctx, event.OrderId)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to deduct stock for order %d: %w", event.OrderId, err)
+	}
+
+	if processed.Status == domain.ProcessedOrderRejected {
+		log.Printf("Rejected order %d: %s", event.OrderId, processed.Message)
+	}
+	if err = h.publisher.PublishOrderProcessed(ctx, processed); err != nil {
+		return fmt.Errorf("failed to publish outcome of order %d: %w", event.OrderId, err)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package kafka
+
+import (
+	"fmt"
+	"github.com/IBM/sarama"
+	"strconv"
+	"time"
+)
+
+// Headers attached to dead-lettered messages, they describe where the message came from and why it failed
+const (
+	HeaderError             = "x-error"
+	HeaderAttempts          = "x-attempts"
+	HeaderFailedAt          = "x-failed-at"
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderReplayedFrom      = "x-replayed-from"
+)
+
+// deadLetterHeaders are dropped when a message is replayed, a replayed message that fails again gets fresh ones
+var deadLetterHeaders = map[string]bool{
+	HeaderError:             true,
+	HeaderAttempts:          true,
+	HeaderFailedAt:          true,
+	HeaderOriginalTopic:     true,
+	HeaderOriginalPartition: true,
+	HeaderOriginalOffset:    true,
+}
+
+// DeadLetterProducer moves messages that could not be processed to the dead-letter topic
+type DeadLetterProducer struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func NewDeadLetterProducer(brokers []string, topic string) (*DeadLetterProducer, error) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	return &DeadLetterProducer{producer: producer, topic: topic}, nil
+}
+
+// Send publishes the failed message unchanged, the error and the original position are added as headers
+func (p *DeadLetterProducer) Send(message *sarama.ConsumerMessage, cause error, attempts int) error {
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+6)
+	for _, header := range message.Headers {
+		if header != nil && !deadLetterHeaders[string(header.Key)] {
+			headers = append(headers, *header)
+		}
+	}
+	headers = append(headers,
+		header(HeaderError, cause.Error()),
+		header(HeaderAttempts, strconv.Itoa(attempts)),
+		header(HeaderFailedAt, time.Now().UTC().Format(time.RFC3339)),
+		header(HeaderOriginalTopic, message.Topic),
+		header(HeaderOriginalPartition, strconv.FormatInt(int64(message.Partition), 10)),
+		header(HeaderOriginalOffset, strconv.FormatInt(message.Offset, 10)),
+	)
+
+	_, _, err := p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   p.topic,
+		Key:     sarama.ByteEncoder(message.Key),
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	})
+	return err
+}
+
+func (p *DeadLetterProducer) Close() error {
+	return p.producer.Close()
+}
+
+// DeadLetter is a message stored in the dead-letter topic
+type DeadLetter struct {
+	Partition int32
+	Offset    int64
+	Timestamp time.Time
+	Key       []byte
+	Value     []byte
+	Headers   []sarama.RecordHeader
+}
+
+// Header returns the value of a header, empty if the message does not have it
+func (l DeadLetter) Header(key string) string {
+	for _, header := range l.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// DeadLetterQueue reads the dead-letter topic and replays its messages, it is meant for operators
+type DeadLetterQueue struct {
+	client   sarama.Client
+	consumer sarama.Consumer
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func NewDeadLetterQueue(brokers []string, topic string) (*DeadLetterQueue, error) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	client, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		_ = consumer.Close()
+		_ = client.Close()
+		return nil, err
+	}
+
+	return &DeadLetterQueue{client: client, consumer: consumer, producer: producer, topic: topic}, nil
+}
+
+// List returns up to limit messages of the dead-letter topic, oldest first per partition. Only messages
+// stored when List is called are read, so it does not wait for new ones.
+func (q *DeadLetterQueue) List(limit int) ([]DeadLetter, error) {
+	partitions, err := q.client.Partitions(q.topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partitions of %s: %w", q.topic, err)
+	}
+
+	var letters []DeadLetter
+	for _, partition := range partitions {
+		if len(letters) >= limit {
+			break
+		}
+
+		oldest, err := q.client.GetOffset(q.topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get oldest offset of partition %d: %w", partition, err)
+		}
+		newest, err := q.client.GetOffset(q.topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get newest offset of partition %d: %w", partition, err)
+		}
+
+		for offset := oldest; offset < newest && len(letters) < limit; offset++ {
+			letter, err := q.Get(partition, offset)
+			if err != nil {
+				return nil, err
+			}
+			letters = append(letters, letter)
+			offset = letter.Offset
+		}
+	}
+	return letters, nil
+}
+
+// Get reads the message stored at the offset of the partition, or the next one if it has been compacted away
+func (q *DeadLetterQueue) Get(partition int32, offset int64) (DeadLetter, error) {
+	pc, err := q.consumer.ConsumePartition(q.topic, partition, offset)
+	if err != nil {
+		return DeadLetter{}, fmt.Errorf("failed to read partition %d at offset %d: %w", partition, offset, err)
+	}
+	defer pc.AsyncClose()
+
+	select {
+	case message := <-pc.Messages():
+		letter := DeadLetter{
+			Partition: message.Partition,
+			Offset:    message.Offset,
+			Timestamp: message.Timestamp,
+			Key:       message.Key,
+			Value:     message.Value,
+		}
+		for _, header := range message.Headers {
+			if header != nil {
+				letter.Headers = append(letter.Headers, *header)
+			}
+		}
+		return letter, nil
+	case err := <-pc.Errors():
+		return DeadLetter{}, fmt.Errorf("failed to read partition %d at offset %d: %w", partition, offset, err)
+	case <-time.After(10 * time.Second):
+		return DeadLetter{}, fmt.Errorf("timed out reading partition %d at offset %d", partition, offset)
+	}
+}
+
+// Replay publishes the message back to the topic it failed on. Replaying is safe for messages that have
+// been processed in the meantime, the consumer does not change the stock of an order twice.
+func (q *DeadLetterQueue) Replay(letter DeadLetter) error {
+	topic := letter.Header(HeaderOriginalTopic)
+	if topic == "" {
+		return fmt.Errorf("message at partition %d offset %d has no %s header", letter.Partition, letter.Offset, HeaderOriginalTopic)
+	}
+
+	headers := make([]sarama.RecordHeader, 0, len(letter.Headers)+1)
+	for _, header := range letter.Headers {
+		if !deadLetterHeaders[string(header.Key)] && string(header.Key) != HeaderReplayedFrom {
+			headers = append(headers, header)
+		}
+	}
+	headers = append(headers, header(HeaderReplayedFrom, fmt.Sprintf("%s/%d/%d", q.topic, letter.Partition, letter.Offset)))
+
+	_, _, err := q.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   topic,
+		Key:     sarama.ByteEncoder(letter.Key),
+		Value:   sarama.ByteEncoder(letter.Value),
+		Headers: headers,
+	})
+	return err
+}
+
+func (q *DeadLetterQueue) Close() error {
+	if err := q.producer.Close(); err != nil {
+		return err
+	}
+	if err := q.consumer.Close(); err != nil {
+		return err
+	}
+	return q.client.Close()
+}
+
+func header(key, value string) sarama.RecordHeader {
+	return sarama.RecordHeader{Key: []byte(key), Value: []byte(value)}
+}
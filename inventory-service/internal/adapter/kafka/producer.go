@@ -0,0 +1,66 @@
+package kafka
+
+import (
+	"context"
+	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	events "github.com/BeksultanSE/Assignment1-inventory/protos/gen/golang"
+	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/proto"
+	"strconv"
+)
+
+const (
+	TopicInventoryReserved = "inventory.reserved"
+	TopicInventoryRejected = "inventory.rejected"
+)
+
+// Producer tells order-service how the stock of its orders has been handled
+type Producer struct {
+	producer sarama.SyncProducer
+}
+
+func NewKafkaProducer(brokers []string) (*Producer, error) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	return &Producer{producer: producer}, nil
+}
+
+// PublishOrderProcessed publishes inventory.reserved for deducted orders and inventory.rejected for rejected ones
+func (p *Producer) PublishOrderProcessed(ctx context.Context, order domain.ProcessedOrder) error {
+	if order.Status == domain.ProcessedOrderRejected {
+		return p.publish(TopicInventoryRejected, order.OrderID, &events.InventoryRejectedEvent{
+			OrderId:    order.OrderID,
+			Reason:     order.Reason,
+			Message:    order.Message,
+			RejectedAt: order.ProcessedAt.Unix(),
+		})
+	}
+
+	return p.publish(TopicInventoryReserved, order.OrderID, &events.InventoryReservedEvent{
+		OrderId:    order.OrderID,
+		ReservedAt: order.ProcessedAt.Unix(),
+	})
+}
+
+// publish keys messages by order id, so all events of an order end up in the same partition
+func (p *Producer) publish(topic string, orderID uint64, event proto.Message) error {
+	eventBytes, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(strconv.FormatUint(orderID, 10)),
+		Value: sarama.ByteEncoder(eventBytes),
+	})
+	return err
+}
+
+func (p *Producer) Close() error {
+	return p.producer.Close()
+}
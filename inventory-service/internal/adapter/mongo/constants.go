@@ -3,4 +3,7 @@ package mongo
 const (
 	CollectionProducts = "products"
 	CollectionAutoInc  = "auto-inc-ids"
+
+	CollectionReservations    = "reservations"
+	CollectionProcessedOrders = "processed-orders"
 )
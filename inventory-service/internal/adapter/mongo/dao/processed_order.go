@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	"time"
+)
+
+type ProcessedOrder struct {
+	OrderID     uint64               `bson:"_id"`
+	Status      string               `bson:"status"`
+	Reason      string               `bson:"reason,omitempty"`
+	Message     string               `bson:"message,omitempty"`
+	Items       []ProcessedOrderItem `bson:"items,omitempty"`
+	ProcessedAt time.Time            `bson:"processedAt"`
+}
+
+type ProcessedOrderItem struct {
+	ProductID uint64 `bson:"productId"`
+	Quantity  uint64 `bson:"quantity"`
+}
+
+func FromProcessedOrder(order domain.ProcessedOrder) ProcessedOrder {
+	items := make([]ProcessedOrderItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = ProcessedOrderItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	return ProcessedOrder{
+		OrderID:     order.OrderID,
+		Status:      string(order.Status),
+		Reason:      order.Reason,
+		Message:     order.Message,
+		Items:       items,
+		ProcessedAt: order.ProcessedAt,
+	}
+}
+
+func ToProcessedOrder(order ProcessedOrder) domain.ProcessedOrder {
+	items := make([]domain.StockItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = domain.StockItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	return domain.ProcessedOrder{
+		OrderID:     order.OrderID,
+		Status:      domain.ProcessedOrderStatus(order.Status),
+		Reason:      order.Reason,
+		Message:     order.Message,
+		Items:       items,
+		ProcessedAt: order.ProcessedAt,
+	}
+}
@@ -7,26 +7,46 @@ import (
 )
 
 type Product struct {
-	ID        uint64    `bson:"_id"`
-	Name      string    `bson:"name"`
-	Category  string    `bson:"category"`
-	Price     float64   `bson:"price"`
-	Stock     uint64    `bson:"stock"`
-	CreatedAt time.Time `bson:"createdAt"`
-	UpdatedAt time.Time `bson:"updatedAt"`
+	ID          uint64           `bson:"_id"`
+	Name        string           `bson:"name"`
+	Category    string           `bson:"category"`
+	Description string           `bson:"description,omitempty"`
+	Price       float64          `bson:"price"`
+	Stock       uint64           `bson:"stock"`
+	Reserved    uint64           `bson:"reserved"`
+	Specs       *MotorcycleSpecs `bson:"specs,omitempty"`
+	CreatedAt   time.Time        `bson:"createdAt"`
+	UpdatedAt   time.Time        `bson:"updatedAt"`
+}
+
+type MotorcycleSpecs struct {
+	Brand         string   `bson:"brand"`
+	Model         string   `bson:"model"`
+	ModelYear     uint32   `bson:"modelYear,omitempty"`
+	EngineCC      uint32   `bson:"engineCc,omitempty"`
+	PowerKW       float64  `bson:"powerKw,omitempty"`
+	WeightKG      float64  `bson:"weightKg,omitempty"`
+	SeatHeightMM  uint32   `bson:"seatHeightMm,omitempty"`
+	FuelCapacityL float64  `bson:"fuelCapacityL,omitempty"`
+	Transmission  string   `bson:"transmission,omitempty"`
+	LicenseClass  string   `bson:"licenseClass,omitempty"`
+	Colorways     []string `bson:"colorways,omitempty"`
 }
 
 func ToProductList(daoProducts []Product) []domain.Product {
 	products := make([]domain.Product, len(daoProducts))
 	for i, p := range daoProducts {
 		products[i] = domain.Product{
-			ID:        p.ID,
-			Name:      p.Name,
-			Category:  p.Category,
-			Price:     p.Price,
-			Stock:     p.Stock,
-			CreatedAt: p.CreatedAt,
-			UpdatedAt: p.UpdatedAt,
+			ID:          p.ID,
+			Name:        p.Name,
+			Category:    p.Category,
+			Description: p.Description,
+			Price:       p.Price,
+			Stock:       p.Stock,
+			Reserved:    p.Reserved,
+			Specs:       ToMotorcycleSpecs(p.Specs),
+			CreatedAt:   p.CreatedAt,
+			UpdatedAt:   p.UpdatedAt,
 		}
 	}
 	return products
@@ -34,25 +54,69 @@ func ToProductList(daoProducts []Product) []domain.Product {
 
 func ToProduct(product Product) domain.Product {
 	return domain.Product{
-		ID:        product.ID,
-		Name:      product.Name,
-		Category:  product.Category,
-		Price:     product.Price,
-		Stock:     product.Stock,
-		CreatedAt: product.CreatedAt,
-		UpdatedAt: product.UpdatedAt,
+		ID:          product.ID,
+		Name:        product.Name,
+		Category:    product.Category,
+		Description: product.Description,
+		Price:       product.Price,
+		Stock:       product.Stock,
+		Reserved:    product.Reserved,
+		Specs:       ToMotorcycleSpecs(product.Specs),
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
 	}
 }
 
 func FromProduct(product domain.Product) Product {
 	return Product{
-		ID:        product.ID,
-		Name:      product.Name,
-		Category:  product.Category,
-		Price:     product.Price,
-		Stock:     product.Stock,
-		CreatedAt: product.CreatedAt,
-		UpdatedAt: product.UpdatedAt,
+		ID:          product.ID,
+		Name:        product.Name,
+		Category:    product.Category,
+		Description: product.Description,
+		Price:       product.Price,
+		Stock:       product.Stock,
+		Reserved:    product.Reserved,
+		Specs:       FromMotorcycleSpecs(product.Specs),
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+	}
+}
+
+func ToMotorcycleSpecs(specs *MotorcycleSpecs) *domain.MotorcycleSpecs {
+	if specs == nil {
+		return nil
+	}
+	return &domain.MotorcycleSpecs{
+		Brand:         specs.Brand,
+		Model:         specs.Model,
+		ModelYear:     specs.ModelYear,
+		EngineCC:      specs.EngineCC,
+		PowerKW:       specs.PowerKW,
+		WeightKG:      specs.WeightKG,
+		SeatHeightMM:  specs.SeatHeightMM,
+		FuelCapacityL: specs.FuelCapacityL,
+		Transmission:  domain.Transmission(specs.Transmission),
+		LicenseClass:  domain.LicenseClass(specs.LicenseClass),
+		Colorways:     specs.Colorways,
+	}
+}
+
+func FromMotorcycleSpecs(specs *domain.MotorcycleSpecs) *MotorcycleSpecs {
+	if specs == nil {
+		return nil
+	}
+	return &MotorcycleSpecs{
+		Brand:         specs.Brand,
+		Model:         specs.Model,
+		ModelYear:     specs.ModelYear,
+		EngineCC:      specs.EngineCC,
+		PowerKW:       specs.PowerKW,
+		WeightKG:      specs.WeightKG,
+		SeatHeightMM:  specs.SeatHeightMM,
+		FuelCapacityL: specs.FuelCapacityL,
+		Transmission:  string(specs.Transmission),
+		LicenseClass:  string(specs.LicenseClass),
+		Colorways:     specs.Colorways,
 	}
 }
 
@@ -67,11 +131,29 @@ func FromProductFilter(filter domain.ProductFilter) bson.M {
 		query["name"] = *filter.Name
 	}
 
-	if filter.Category != nil {
+	if len(filter.Categories) > 0 {
+		categories := filter.Categories
+		if filter.Category != nil {
+			categories = append([]string{*filter.Category}, categories...)
+		}
+		query["category"] = bson.M{"$in": categories}
+	} else if filter.Category != nil {
 		query["category"] = *filter.Category
 	}
 
-	if filter.Price != nil {
+	if filter.PriceMin != nil || filter.PriceMax != nil {
+		price := bson.M{}
+		if filter.Price != nil {
+			price["$eq"] = *filter.Price
+		}
+		if filter.PriceMin != nil {
+			price["$gte"] = *filter.PriceMin
+		}
+		if filter.PriceMax != nil {
+			price["$lte"] = *filter.PriceMax
+		}
+		query["price"] = price
+	} else if filter.Price != nil {
 		query["price"] = *filter.Price
 	}
 
@@ -79,9 +161,73 @@ func FromProductFilter(filter domain.ProductFilter) bson.M {
 		query["stock"] = *filter.Stock
 	}
 
+	if filter.InStockOnly {
+		// stock > 0 can use an index, the units held by reservations are subtracted afterwards
+		if filter.Stock == nil {
+			query["stock"] = bson.M{"$gt": 0}
+		}
+		query["$expr"] = bson.M{"$gt": bson.A{bson.M{"$subtract": bson.A{"$stock", bson.M{"$ifNull": bson.A{"$reserved", 0}}}}, 0}}
+	}
+
+	if filter.Brand != nil {
+		query["specs.brand"] = *filter.Brand
+	}
+
+	if engineCC := rangeQuery(filter.EngineCCMin, filter.EngineCCMax); engineCC != nil {
+		query["specs.engineCc"] = engineCC
+	}
+
+	if modelYear := rangeQuery(filter.ModelYearMin, filter.ModelYearMax); modelYear != nil {
+		query["specs.modelYear"] = modelYear
+	}
+
+	if len(filter.LicenseClasses) > 0 {
+		query["specs.licenseClass"] = bson.M{"$in": filter.LicenseClasses}
+	}
+
+	if filter.Transmission != nil {
+		query["specs.transmission"] = *filter.Transmission
+	}
+
 	return query
 }
 
+// rangeQuery matches values between min and max, both included, nil when neither bound is set
+func rangeQuery(min, max *uint32) bson.M {
+	if min == nil && max == nil {
+		return nil
+	}
+	bounds := bson.M{}
+	if min != nil {
+		bounds["$gte"] = *min
+	}
+	if max != nil {
+		bounds["$lte"] = *max
+	}
+	return bounds
+}
+
+// productSortFields maps the sort fields to the stored ones
+var productSortFields = map[domain.ProductSortField]string{
+	domain.ProductSortPrice:     "price",
+	domain.ProductSortName:      "name",
+	domain.ProductSortCreatedAt: "createdAt",
+	domain.ProductSortStock:     "stock",
+}
+
+// FromProductSort converts the sort to a mongo sort document, the id is always the last key so pages are stable
+func FromProductSort(sort domain.ProductSort) bson.D {
+	direction := 1
+	if sort.Descending {
+		direction = -1
+	}
+
+	if field, ok := productSortFields[sort.Field]; ok {
+		return bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}
+	}
+	return bson.D{{Key: "_id", Value: direction}}
+}
+
 func FromProductUpdateData(updateData domain.ProductUpdateData) bson.M {
 	query := bson.M{}
 
@@ -93,6 +239,10 @@ func FromProductUpdateData(updateData domain.ProductUpdateData) bson.M {
 		query["category"] = *updateData.Category
 	}
 
+	if updateData.Description != nil {
+		query["description"] = *updateData.Description
+	}
+
 	if updateData.Price != nil {
 		query["price"] = *updateData.Price
 	}
@@ -101,9 +251,41 @@ func FromProductUpdateData(updateData domain.ProductUpdateData) bson.M {
 		query["stock"] = *updateData.Stock
 	}
 
+	if updateData.Specs != nil {
+		query["specs"] = FromMotorcycleSpecs(updateData.Specs)
+	}
+
 	if updateData.UpdatedAt != nil {
 		query["updatedAt"] = updateData.UpdatedAt
 	}
 
 	return bson.M{"$set": query}
 }
+
+// FromProductCursor matches the products coming after the cursor in the order of its sort
+func FromProductCursor(cursor domain.ProductCursor) bson.M {
+	after := "$gt"
+	if cursor.Sort.Descending {
+		after = "$lt"
+	}
+
+	var value interface{}
+	switch cursor.Sort.Field {
+	case domain.ProductSortPrice:
+		value = cursor.Price
+	case domain.ProductSortName:
+		value = cursor.Name
+	case domain.ProductSortCreatedAt:
+		value = cursor.CreatedAt
+	case domain.ProductSortStock:
+		value = cursor.Stock
+	default:
+		return bson.M{"_id": bson.M{after: cursor.ID}}
+	}
+
+	field := productSortFields[cursor.Sort.Field]
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{after: value}},
+		bson.M{field: value, "_id": bson.M{after: cursor.ID}},
+	}}
+}
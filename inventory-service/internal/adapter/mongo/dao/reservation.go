@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	"time"
+)
+
+type Reservation struct {
+	OrderID   uint64            `bson:"_id"`
+	Items     []ReservationItem `bson:"items"`
+	Status    string            `bson:"status"`
+	ExpiresAt time.Time         `bson:"expiresAt"`
+	CreatedAt time.Time         `bson:"createdAt"`
+	UpdatedAt time.Time         `bson:"updatedAt"`
+}
+
+type ReservationItem struct {
+	ProductID uint64 `bson:"productId"`
+	Quantity  uint64 `bson:"quantity"`
+}
+
+func FromReservation(reservation domain.Reservation) Reservation {
+	items := make([]ReservationItem, len(reservation.Items))
+	for i, item := range reservation.Items {
+		items[i] = ReservationItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	return Reservation{
+		OrderID:   reservation.OrderID,
+		Items:     items,
+		Status:    string(reservation.Status),
+		ExpiresAt: reservation.ExpiresAt,
+		CreatedAt: reservation.CreatedAt,
+		UpdatedAt: reservation.UpdatedAt,
+	}
+}
+
+func ToReservation(reservation Reservation) domain.Reservation {
+	items := make([]domain.StockItem, len(reservation.Items))
+	for i, item := range reservation.Items {
+		items[i] = domain.StockItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	return domain.Reservation{
+		OrderID:   reservation.OrderID,
+		Items:     items,
+		Status:    domain.ReservationStatus(reservation.Status),
+		ExpiresAt: reservation.ExpiresAt,
+		CreatedAt: reservation.CreatedAt,
+		UpdatedAt: reservation.UpdatedAt,
+	}
+}
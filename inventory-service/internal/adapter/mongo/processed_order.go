@@ -0,0 +1,99 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-inventory/internal/adapter/mongo/dao"
+	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ProcessedOrderRepo keeps the ids of the orders whose stock has been handled. The order id is the _id of
+// the record, its unique index rejects a second record of the same order.
+type ProcessedOrderRepo struct {
+	conn       *mongo.Database
+	collection string
+}
+
+func NewProcessedOrderRepo(conn *mongo.Database) *ProcessedOrderRepo {
+	return &ProcessedOrderRepo{
+		conn:       conn,
+		collection: CollectionProcessedOrders,
+	}
+}
+
+// Create records the order, domain.ErrOrderAlreadyProcessed is returned if it has been recorded before
+func (r *ProcessedOrderRepo) Create(ctx context.Context, order domain.ProcessedOrder) error {
+	_, err := r.conn.Collection(r.collection).InsertOne(ctx, dao.FromProcessedOrder(order))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("%w: order %d", domain.ErrOrderAlreadyProcessed, order.OrderID)
+		}
+		return fmt.Errorf("processed order %d has not been recorded: %w", order.OrderID, err)
+	}
+	return nil
+}
+
+func (r *ProcessedOrderRepo) Get(ctx context.Context, orderID uint64) (domain.ProcessedOrder, error) {
+	var orderDao dao.ProcessedOrder
+	err := r.conn.Collection(r.collection).FindOne(ctx, bson.M{"_id": orderID}).Decode(&orderDao)
+	if err != nil {
+		return domain.ProcessedOrder{}, fmt.Errorf("failed to find processed order %d: %w", orderID, err)
+	}
+	return dao.ToProcessedOrder(orderDao), nil
+}
+
+// Reject marks a recorded order as rejected, its stock has been left untouched
+func (r *ProcessedOrderRepo) Reject(ctx context.Context, orderID uint64, reason, message string) error {
+	_, err := r.conn.Collection(r.collection).UpdateOne(
+		ctx,
+		bson.M{"_id": orderID},
+		bson.M{"$set": bson.M{"status": domain.ProcessedOrderRejected, "reason": reason, "message": message}},
+	)
+	if err != nil {
+		return fmt.Errorf("processed order %d has not been rejected: %w", orderID, err)
+	}
+	return nil
+}
+
+// Restock marks a deducted order as restocked and returns it as it was before, with its items.
+// domain.ErrOrderNotDeducted is returned when the order has not been deducted or has been restocked already.
+func (r *ProcessedOrderRepo) Restock(ctx context.Context, orderID uint64) (domain.ProcessedOrder, error) {
+	var orderDao dao.ProcessedOrder
+	err := r.conn.Collection(r.collection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": orderID, "status": domain.ProcessedOrderDeducted},
+		bson.M{"$set": bson.M{"status": domain.ProcessedOrderRestocked}},
+	).Decode(&orderDao)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.ProcessedOrder{}, fmt.Errorf("%w: order %d", domain.ErrOrderNotDeducted, orderID)
+		}
+		return domain.ProcessedOrder{}, fmt.Errorf("processed order %d has not been restocked: %w", orderID, err)
+	}
+	return dao.ToProcessedOrder(orderDao), nil
+}
+
+// UndoRestock marks a restocked order as deducted again when its stock could not be returned
+func (r *ProcessedOrderRepo) UndoRestock(ctx context.Context, orderID uint64) error {
+	_, err := r.conn.Collection(r.collection).UpdateOne(
+		ctx,
+		bson.M{"_id": orderID, "status": domain.ProcessedOrderRestocked},
+		bson.M{"$set": bson.M{"status": domain.ProcessedOrderDeducted}},
+	)
+	if err != nil {
+		return fmt.Errorf("restock of processed order %d has not been undone: %w", orderID, err)
+	}
+	return nil
+}
+
+// Delete removes the record of an order whose stock could not be handled, so the event can be processed again
+func (r *ProcessedOrderRepo) Delete(ctx context.Context, orderID uint64) error {
+	_, err := r.conn.Collection(r.collection).DeleteOne(ctx, bson.M{"_id": orderID})
+	if err != nil {
+		return fmt.Errorf("processed order %d has not been deleted: %w", orderID, err)
+	}
+	return nil
+}
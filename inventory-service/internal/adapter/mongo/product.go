@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"log"
+	"regexp"
+	"time"
 
 	"github.com/BeksultanSE/Assignment1-inventory/internal/adapter/mongo/dao"
 	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
@@ -26,6 +30,40 @@ func NewProductRepo(conn *mongo.Database) *ProductRepo {
 	}
 }
 
+// EnsureIndexes creates the text index used by product search, matches in the name weigh the most, and the
+// indexes backing the common listings: sorted by price, name, newest or stock, optionally within categories,
+// and motorcycles by license class and engine size or by brand
+func (p *ProductRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := p.conn.Collection(p.collection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "name", Value: "text"},
+				{Key: "category", Value: "text"},
+				{Key: "description", Value: "text"},
+			},
+			Options: options.Index().
+				SetName("product_text").
+				SetWeights(bson.D{
+					{Key: "name", Value: domain.SearchWeightName},
+					{Key: "category", Value: domain.SearchWeightCategory},
+					{Key: "description", Value: domain.SearchWeightDescription},
+				}),
+		},
+		{Keys: bson.D{{Key: "category", Value: 1}, {Key: "price", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "category", Value: 1}, {Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "price", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "stock", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "specs.licenseClass", Value: 1}, {Key: "specs.engineCc", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "specs.brand", Value: 1}, {Key: "specs.engineCc", Value: 1}, {Key: "_id", Value: 1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create product indexes: %w", err)
+	}
+	return nil
+}
+
 // Create inserts a new product into the database
 func (p *ProductRepo) Create(ctx context.Context, product domain.Product) error {
 	productDoc := dao.FromProduct(product)
@@ -73,29 +111,99 @@ func (p *ProductRepo) GetWithFilter(ctx context.Context, filter domain.ProductFi
 	return product, nil
 }
 
-// GetListWithFilter retrieves multiple products based on a filter
-func (p *ProductRepo) GetListWithFilter(ctx context.Context, filter domain.ProductFilter, page, limit int64) ([]domain.Product, int, error) {
+// GetByIDs retrieves the products with the given ids in one query, ids without a product are skipped
+func (p *ProductRepo) GetByIDs(ctx context.Context, ids []uint64) ([]domain.Product, error) {
+	cursor, err := p.conn.Collection(p.collection).Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find products: %w", err)
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("failed to close cursor: %v", err)
+		}
+	}(cursor, ctx)
+
+	var daoProducts []dao.Product
+	if err := cursor.All(ctx, &daoProducts); err != nil {
+		return nil, fmt.Errorf("failed to decode products: %w", err)
+	}
+	return dao.ToProductList(daoProducts), nil
+}
+
+// GetListWithFilter retrieves a page of products based on a filter. Pages after a cursor are found by the
+// sort key, so deep pages stay fast and rows inserted in between do not shift them.
+func (p *ProductRepo) GetListWithFilter(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort, page domain.ProductPage) (domain.ProductList, error) {
 	// Create the filter for the query
 	findFilter := dao.FromProductFilter(filter)
 
-	// Calculate pagination parameters
-	skip := (page - 1) * limit
-
-	// Set up find options for pagination
+	// one more product than asked for tells whether there is a next page
 	findOptions := options.Find()
-	findOptions.SetSkip(int64(skip))
-	findOptions.SetLimit(int64(limit))
+	findOptions.SetSort(dao.FromProductSort(sort))
+	if page.Limit > 0 {
+		findOptions.SetLimit(page.Limit + 1)
+	}
+
+	var totalCount int64
+	if !page.SkipTotal {
+		var err error
+		totalCount, err = p.conn.Collection(p.collection).CountDocuments(ctx, findFilter)
+		if err != nil {
+			return domain.ProductList{}, err
+		}
+	}
+
+	if page.After != nil {
+		findFilter["$and"] = bson.A{dao.FromProductCursor(*page.After)}
+	} else if page.Page > 1 {
+		findOptions.SetSkip((page.Page - 1) * page.Limit)
+	}
+
+	// Execute the query with pagination
+	cursor, err := p.conn.Collection(p.collection).Find(ctx, findFilter, findOptions)
+	if err != nil {
+		return domain.ProductList{}, fmt.Errorf("failed to find products: %w", err)
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("failed to close cursor: %v", err)
+		}
+	}(cursor, ctx)
+
+	var daoProducts []dao.Product
+	if err := cursor.All(ctx, &daoProducts); err != nil {
+		return domain.ProductList{}, fmt.Errorf("failed to decode products: %w", err)
+	}
+
+	list := domain.ProductList{Products: dao.ToProductList(daoProducts), Total: int(totalCount)}
+	if page.Limit > 0 && int64(len(list.Products)) > page.Limit {
+		list.Products = list.Products[:page.Limit]
+		list.Next = domain.NewProductCursor(sort, list.Products[page.Limit-1])
+	}
+	return list, nil
+}
+
+// SearchText finds the products containing any word of the query, stemmed the way the text index stores
+// them, and orders them by relevance
+func (p *ProductRepo) SearchText(ctx context.Context, query string, page, limit int64) ([]domain.Product, int, error) {
+	findFilter := bson.M{"$text": bson.M{"$search": query}}
 
-	// Get total count
 	totalCount, err := p.conn.Collection(p.collection).CountDocuments(ctx, findFilter)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, fmt.Errorf("failed to count products: %w", err)
+	}
+	if totalCount == 0 {
+		return nil, 0, nil
 	}
 
-	// Execute the query with pagination
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: 1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+
 	cursor, err := p.conn.Collection(p.collection).Find(ctx, findFilter, findOptions)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to find products: %w", err)
+		return nil, 0, fmt.Errorf("failed to search products: %w", err)
 	}
 	defer func(cursor *mongo.Cursor, ctx context.Context) {
 		if err := cursor.Close(ctx); err != nil {
@@ -107,8 +215,42 @@ func (p *ProductRepo) GetListWithFilter(ctx context.Context, filter domain.Produ
 	if err := cursor.All(ctx, &daoProducts); err != nil {
 		return nil, 0, fmt.Errorf("failed to decode products: %w", err)
 	}
-	products := dao.ToProductList(daoProducts)
-	return products, int(totalCount), nil
+	return dao.ToProductList(daoProducts), int(totalCount), nil
+}
+
+// FindByWordPrefixes returns up to limit products with a word in the name, category or description starting
+// with one of the prefixes, case-insensitive
+func (p *ProductRepo) FindByWordPrefixes(ctx context.Context, prefixes []string, limit int64) ([]domain.Product, error) {
+	conditions := bson.A{}
+	for _, prefix := range prefixes {
+		pattern := primitive.Regex{Pattern: `\b` + regexp.QuoteMeta(prefix), Options: "i"}
+		for _, field := range []string{"name", "category", "description"} {
+			conditions = append(conditions, bson.M{field: pattern})
+		}
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := p.conn.Collection(p.collection).Find(
+		ctx,
+		bson.M{"$or": conditions},
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find products: %w", err)
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("failed to close cursor: %v", err)
+		}
+	}(cursor, ctx)
+
+	var daoProducts []dao.Product
+	if err := cursor.All(ctx, &daoProducts); err != nil {
+		return nil, fmt.Errorf("failed to decode products: %w", err)
+	}
+	return dao.ToProductList(daoProducts), nil
 }
 
 // Delete permanently deletes a product from the database
@@ -127,3 +269,118 @@ func (p *ProductRepo) Delete(ctx context.Context, filter domain.ProductFilter) e
 
 	return nil
 }
+
+// ReserveStock holds the quantities of all items. A product is only reserved while its available units
+// (stock minus reserved) cover the quantity, so concurrent reservations can never oversell. If any item cannot
+// be reserved the items applied before it are rolled back and domain.ErrInsufficientStock is returned.
+func (p *ProductRepo) ReserveStock(ctx context.Context, items []domain.StockItem, now time.Time) error {
+	return p.applyStockChange(ctx, items, now, stockChange{
+		guard: func(q int64) bson.M {
+			// products created before reservations existed have no reserved field
+			available := bson.M{"$subtract": bson.A{"$stock", bson.M{"$ifNull": bson.A{"$reserved", 0}}}}
+			return bson.M{"$expr": bson.M{"$gte": bson.A{available, q}}}
+		},
+		inc: func(q int64) bson.M { return bson.M{"reserved": q} },
+	})
+}
+
+// ReleaseStock makes reserved quantities available again, e.g. when an order is cancelled or its hold expires
+func (p *ProductRepo) ReleaseStock(ctx context.Context, items []domain.StockItem, now time.Time) error {
+	return p.applyStockChange(ctx, items, now, stockChange{
+		guard: func(q int64) bson.M { return bson.M{"reserved": bson.M{"$gte": q}} },
+		inc:   func(q int64) bson.M { return bson.M{"reserved": -q} },
+	})
+}
+
+// CommitStock removes reserved quantities from stock for good once the order is fulfilled
+func (p *ProductRepo) CommitStock(ctx context.Context, items []domain.StockItem, now time.Time) error {
+	return p.applyStockChange(ctx, items, now, stockChange{
+		guard: func(q int64) bson.M { return bson.M{"reserved": bson.M{"$gte": q}, "stock": bson.M{"$gte": q}} },
+		inc:   func(q int64) bson.M { return bson.M{"stock": -q, "reserved": -q} },
+	})
+}
+
+// ReturnStock puts the committed quantities back into stock, e.g. for an order cancelled after its deduction
+func (p *ProductRepo) ReturnStock(ctx context.Context, items []domain.StockItem, now time.Time) error {
+	return p.applyStockChange(ctx, items, now, stockChange{
+		guard: func(q int64) bson.M { return bson.M{} },
+		inc:   func(q int64) bson.M { return bson.M{"stock": q} },
+	})
+}
+
+// stockChange is a conditional $inc, it is only applied to a product matching the guard for the quantity
+type stockChange struct {
+	guard func(quantity int64) bson.M
+	inc   func(quantity int64) bson.M
+}
+
+// applyStockChange applies the change to every item, all or nothing. Standalone mongo deployments
+// have no transactions, so the items already changed are compensated with the inverse $inc on failure.
+func (p *ProductRepo) applyStockChange(ctx context.Context, items []domain.StockItem, now time.Time, change stockChange) error {
+	collection := p.conn.Collection(p.collection)
+
+	// items of order.created events have not been validated, a quantity beyond int64 would flip the $inc
+	for _, item := range items {
+		if item.Quantity > domain.MaxStockQuantity {
+			return fmt.Errorf("%w: quantity %d of product %d is too large", domain.ErrInsufficientStock, item.Quantity, item.ProductID)
+		}
+	}
+
+	for i, item := range items {
+		quantity := int64(item.Quantity)
+		filter := change.guard(quantity)
+		filter["_id"] = item.ProductID
+
+		res, err := collection.UpdateOne(
+			ctx,
+			filter,
+			bson.M{"$inc": change.inc(quantity), "$set": bson.M{"updatedAt": now}},
+		)
+		if err == nil && res.MatchedCount == 1 {
+			continue
+		}
+
+		p.rollbackStockChange(ctx, items[:i], now, change)
+		if err != nil {
+			return fmt.Errorf("failed to change stock of product %d: %w", item.ProductID, err)
+		}
+		return p.stockChangeError(ctx, item.ProductID)
+	}
+
+	return nil
+}
+
+// rollbackStockChange undoes a partially applied change, failures are logged as there is no way to recover
+// from them here
+func (p *ProductRepo) rollbackStockChange(ctx context.Context, applied []domain.StockItem, now time.Time, change stockChange) {
+	// the rollback has to run even if the request that triggered the change was cancelled
+	ctx = context.WithoutCancel(ctx)
+
+	for _, item := range applied {
+		inverse := bson.M{}
+		for field, delta := range change.inc(int64(item.Quantity)) {
+			inverse[field] = -delta.(int64)
+		}
+
+		_, err := p.conn.Collection(p.collection).UpdateOne(
+			ctx,
+			bson.M{"_id": item.ProductID},
+			bson.M{"$inc": inverse, "$set": bson.M{"updatedAt": now}},
+		)
+		if err != nil {
+			log.Printf("failed to roll back stock change of product %d by %d: %v", item.ProductID, item.Quantity, err)
+		}
+	}
+}
+
+// stockChangeError tells a missing product apart from one without enough stock
+func (p *ProductRepo) stockChangeError(ctx context.Context, productID uint64) error {
+	count, err := p.conn.Collection(p.collection).CountDocuments(ctx, bson.M{"_id": productID})
+	if err != nil {
+		return fmt.Errorf("failed to find product %d: %w", productID, err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: product %d", domain.ErrProductNotFound, productID)
+	}
+	return fmt.Errorf("%w: product %d", domain.ErrInsufficientStock, productID)
+}
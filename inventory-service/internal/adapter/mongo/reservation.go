@@ -0,0 +1,150 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-inventory/internal/adapter/mongo/dao"
+	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// reservationRetention is how long a reservation is kept after its hold ran out, it only matters for
+// finished reservations as held ones are expired by the sweeper long before
+const reservationRetention = 7 * 24 * time.Hour
+
+// ReservationRepo stores the holds orders have on stock, one document per order
+type ReservationRepo struct {
+	conn       *mongo.Database
+	collection string
+}
+
+func NewReservationRepo(conn *mongo.Database) *ReservationRepo {
+	return &ReservationRepo{
+		conn:       conn,
+		collection: CollectionReservations,
+	}
+}
+
+// EnsureIndexes speeds up the search for expired holds and lets mongo drop old reservations on its own
+func (r *ReservationRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.conn.Collection(r.collection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "expiresAt", Value: 1}},
+		},
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(reservationRetention.Seconds())),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create reservation indexes: %w", err)
+	}
+	return nil
+}
+
+// Create stores a new hold for the order. A released or expired reservation of the same order is replaced,
+// domain.ErrReservationExists is returned while the order still holds stock or has committed it.
+func (r *ReservationRepo) Create(ctx context.Context, reservation domain.Reservation) error {
+	_, err := r.conn.Collection(r.collection).ReplaceOne(
+		ctx,
+		bson.M{
+			"_id":    reservation.OrderID,
+			"status": bson.M{"$in": bson.A{domain.ReservationReleased, domain.ReservationExpired}},
+		},
+		dao.FromReservation(reservation),
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("%w: order %d", domain.ErrReservationExists, reservation.OrderID)
+		}
+		return fmt.Errorf("reservation of order %d has not been created: %w", reservation.OrderID, err)
+	}
+	return nil
+}
+
+func (r *ReservationRepo) Get(ctx context.Context, orderID uint64) (domain.Reservation, error) {
+	var reservationDao dao.Reservation
+	err := r.conn.Collection(r.collection).FindOne(ctx, bson.M{"_id": orderID}).Decode(&reservationDao)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Reservation{}, domain.ErrReservationNotFound
+		}
+		return domain.Reservation{}, fmt.Errorf("failed to find reservation of order %d: %w", orderID, err)
+	}
+	return dao.ToReservation(reservationDao), nil
+}
+
+// Commit marks a hold that has not run out yet as committed and returns it
+func (r *ReservationRepo) Commit(ctx context.Context, orderID uint64, now time.Time) (domain.Reservation, error) {
+	return r.claim(ctx, orderID, bson.M{"_id": orderID, "status": domain.ReservationHeld, "expiresAt": bson.M{"$gt": now}}, domain.ReservationCommitted, now)
+}
+
+// Release marks a hold as released and returns it. Holds that ran out but have not been swept yet can still be
+// released, their units have not been returned to stock so far.
+func (r *ReservationRepo) Release(ctx context.Context, orderID uint64, now time.Time) (domain.Reservation, error) {
+	return r.claim(ctx, orderID, bson.M{"_id": orderID, "status": domain.ReservationHeld}, domain.ReservationReleased, now)
+}
+
+// Expire marks the hold that ran out first as expired and returns it, domain.ErrReservationNotFound is
+// returned once there are none left
+func (r *ReservationRepo) Expire(ctx context.Context, now time.Time) (domain.Reservation, error) {
+	var reservationDao dao.Reservation
+	err := r.conn.Collection(r.collection).FindOneAndUpdate(
+		ctx,
+		bson.M{"status": domain.ReservationHeld, "expiresAt": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"status": domain.ReservationExpired, "updatedAt": now}},
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "expiresAt", Value: 1}}),
+	).Decode(&reservationDao)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Reservation{}, domain.ErrReservationNotFound
+		}
+		return domain.Reservation{}, fmt.Errorf("failed to expire reservation: %w", err)
+	}
+	return dao.ToReservation(reservationDao), nil
+}
+
+// Restore puts a reservation back on hold when its stock could not be changed after it was claimed
+func (r *ReservationRepo) Restore(ctx context.Context, orderID uint64, from domain.ReservationStatus, now time.Time) error {
+	_, err := r.conn.Collection(r.collection).UpdateOne(
+		ctx,
+		bson.M{"_id": orderID, "status": from},
+		bson.M{"$set": bson.M{"status": domain.ReservationHeld, "updatedAt": now}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to restore reservation of order %d: %w", orderID, err)
+	}
+	return nil
+}
+
+// claim moves a held reservation matching the filter to the given status. Only one caller can claim a
+// reservation, so its stock is released or committed exactly once.
+func (r *ReservationRepo) claim(ctx context.Context, orderID uint64, filter bson.M, to domain.ReservationStatus, now time.Time) (domain.Reservation, error) {
+	var reservationDao dao.Reservation
+	err := r.conn.Collection(r.collection).FindOneAndUpdate(
+		ctx,
+		filter,
+		bson.M{"$set": bson.M{"status": to, "updatedAt": now}},
+	).Decode(&reservationDao)
+	if err == nil {
+		return dao.ToReservation(reservationDao), nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.Reservation{}, fmt.Errorf("failed to update reservation of order %d: %w", orderID, err)
+	}
+
+	// tell apart why the reservation could not be claimed
+	reservation, err := r.Get(ctx, orderID)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+	if reservation.Status == domain.ReservationExpired || (reservation.Status == domain.ReservationHeld && !reservation.ExpiresAt.After(now)) {
+		return domain.Reservation{}, fmt.Errorf("%w: order %d", domain.ErrReservationExpired, orderID)
+	}
+	return domain.Reservation{}, fmt.Errorf("%w: order %d has been %s", domain.ErrReservationNotFound, orderID, reservation.Status)
+}
@@ -45,6 +45,38 @@ func (r *RedisCache) Get(ctx context.Context, productID uint64) (domain.Product,
 	return product, nil
 }
 
+// GetMany reads the cached products with a single MGET, ids that are not cached are left out of the map
+func (r *RedisCache) GetMany(ctx context.Context, productIDs []uint64) (map[uint64]domain.Product, error) {
+	products := make(map[uint64]domain.Product, len(productIDs))
+	if len(productIDs) == 0 {
+		return products, nil
+	}
+
+	keys := make([]string, len(productIDs))
+	for i, id := range productIDs {
+		keys[i] = r.key(id)
+	}
+
+	values, err := r.client.Unwrap().MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue // cache miss
+		}
+
+		var product domain.Product
+		if err = json.Unmarshal([]byte(data), &product); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal product: %w", err)
+		}
+		products[product.ID] = product
+	}
+	return products, nil
+}
+
 func (r *RedisCache) Set(ctx context.Context, product domain.Product) error {
 	data, err := json.Marshal(product)
 	if err != nil {
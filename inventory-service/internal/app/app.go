@@ -17,6 +17,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 const serviceName = "inventory-service"
@@ -27,6 +28,12 @@ type App struct {
 	grpcServer    *grpcAPI.ServerAPI
 	consumerGroup sarama.ConsumerGroup
 	kafkaHandler  *kafka.Consumer
+	dlqProducer   *kafka.DeadLetterProducer
+	kafkaProd     *kafka.Producer
+
+	reservationUsecase *usecase.Reservation
+	sweepInterval      time.Duration
+	stopSweeper        context.CancelFunc
 }
 
 func New(ctx context.Context, cfg *config.Config) (*App, error) {
@@ -40,6 +47,14 @@ func New(ctx context.Context, cfg *config.Config) (*App, error) {
 
 	aiRepo := mongoRepo.NewAutoInc(mongoDB.Conn)
 	pRepo := mongoRepo.NewProductRepo(mongoDB.Conn)
+	if err = pRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("error preparing product collection: %v", err)
+	}
+	processedRepo := mongoRepo.NewProcessedOrderRepo(mongoDB.Conn)
+	reservationRepo := mongoRepo.NewReservationRepo(mongoDB.Conn)
+	if err = reservationRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("error preparing reservation collection: %v", err)
+	}
 
 	// connecting to redis client
 	redisClient, err := redisconn.NewClient(ctx, redisconn.Config(cfg.Redis))
@@ -51,10 +66,11 @@ func New(ctx context.Context, cfg *config.Config) (*App, error) {
 	// redis cache
 	productRedisCache := redis.NewRedisCache(redisClient, cfg.Cache.TTL)
 	
-	pUsecase := usecase.NewProduct(aiRepo, pRepo, productRedisCache)
+	pUsecase := usecase.NewProduct(aiRepo, pRepo, processedRepo, reservationRepo, mongoDB, productRedisCache)
+	reservationUsecase := usecase.NewReservation(reservationRepo, pRepo, productRedisCache, cfg.Reservation.HoldTTL, cfg.Reservation.MaxHoldTTL)
 
 	//httpServer := httpRepo.New(cfg.Server, pUsecase)
-	grpcServer := grpcAPI.New(cfg.Server, pUsecase)
+	grpcServer := grpcAPI.New(cfg.Server, pUsecase, reservationUsecase)
 
 	kafkaConfig := sarama.NewConfig()
 	kafkaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
@@ -62,13 +78,31 @@ func New(ctx context.Context, cfg *config.Config) (*App, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create consumer group: %w", err)
 	}
-	kafkaHandler := kafka.NewConsumer(pUsecase, "order.created")
+	dlqProducer, err := kafka.NewDeadLetterProducer(cfg.Brokers, cfg.Consumer.DeadLetterTopic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dead-letter producer: %w", err)
+	}
+	retryPolicy := kafka.RetryPolicy{
+		MaxRetries: cfg.Consumer.MaxRetries,
+		Backoff:    cfg.Consumer.RetryBackoff,
+		MaxBackoff: cfg.Consumer.MaxRetryBackoff,
+	}
+	producer, err := kafka.NewKafkaProducer(cfg.Brokers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+	kafkaHandler := kafka.NewConsumer(pUsecase, "order.created", retryPolicy, dlqProducer, producer)
 
 	app := &App{
 		//httpServer: httpServer,
 		grpcServer:    grpcServer,
 		consumerGroup: consumerGroup,
 		kafkaHandler:  kafkaHandler,
+		dlqProducer:   dlqProducer,
+		kafkaProd:     producer,
+
+		reservationUsecase: reservationUsecase,
+		sweepInterval:      cfg.Reservation.SweepInterval,
 	}
 
 	return app, nil
@@ -89,6 +123,11 @@ func (app *App) Start() error {
 		}
 	}()
 
+	// Return the units of expired holds to available stock in background
+	sweeperCtx, stopSweeper := context.WithCancel(context.Background())
+	app.stopSweeper = stopSweeper
+	go app.sweepReservations(sweeperCtx)
+
 	log.Printf(fmt.Sprintf("Starting %s service...", serviceName))
 
 	shutdownCh := make(chan os.Signal, 1)
@@ -116,4 +155,37 @@ func (app *App) Stop() {
 	if err := app.consumerGroup.Close(); err != nil {
 		log.Println("failed to close consumer group:", err)
 	}
+
+	if err := app.dlqProducer.Close(); err != nil {
+		log.Println("failed to close dead-letter producer:", err)
+	}
+
+	if err := app.kafkaProd.Close(); err != nil {
+		log.Println("failed to close Kafka producer:", err)
+	}
+
+	if app.stopSweeper != nil {
+		app.stopSweeper()
+	}
+}
+
+// sweepReservations releases the holds that ran out every sweep interval until the context is cancelled
+func (app *App) sweepReservations(ctx context.Context) {
+	ticker := time.NewTicker(app.sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := app.reservationUsecase.ReleaseExpired(ctx)
+			if err != nil {
+				log.Printf("Reservation sweeper error: %v", err)
+			}
+			if released > 0 {
+				log.Printf("Released %d expired reservations", released)
+			}
+		}
+	}
 }
@@ -5,4 +5,11 @@ import "errors"
 var (
 	ErrProductNotFound   = errors.New("product not found")
 	ErrInsufficientStock = errors.New("insufficient stock")
+
+	ErrReservationNotFound = errors.New("reservation not found")
+	ErrReservationExists   = errors.New("reservation already exists")
+	ErrReservationExpired  = errors.New("reservation expired")
+
+	ErrOrderAlreadyProcessed = errors.New("order already processed")
+	ErrOrderNotDeducted      = errors.New("order stock is not deducted")
 )
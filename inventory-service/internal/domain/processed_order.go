@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+type ProcessedOrderStatus string
+
+const (
+	ProcessedOrderDeducted ProcessedOrderStatus = "deducted"
+	ProcessedOrderRejected ProcessedOrderStatus = "rejected"
+	// ProcessedOrderRestocked orders had their stock deducted and returned, e.g. when the order was cancelled
+	// before it could be confirmed
+	ProcessedOrderRestocked ProcessedOrderStatus = "restocked"
+)
+
+// Reasons an order is rejected for
+const (
+	RejectReasonInsufficientStock = "insufficient_stock"
+	RejectReasonProductNotFound   = "product_not_found"
+)
+
+// ProcessedOrder records that the stock of an order.created event has been handled, so a redelivered
+// event does not change the stock a second time
+type ProcessedOrder struct {
+	OrderID     uint64
+	Status      ProcessedOrderStatus
+	Reason      string // why the order has been rejected, one of the RejectReason constants
+	Message     string
+	Items       []StockItem // the deducted items, returned to stock on restock
+	ProcessedAt time.Time
+}
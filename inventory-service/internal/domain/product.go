@@ -1,29 +1,174 @@
 package domain
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 type Product struct {
+	ID          uint64
+	Name        string
+	Category    string
+	Description string
+	Price       float64
+	Stock       uint64           // physical units in the warehouse
+	Reserved    uint64           // units held by reservations that have not been committed or released yet
+	Specs       *MotorcycleSpecs // nil for products that are not motorcycles
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// LicenseClass is the driving licence category needed to ride a motorcycle
+type LicenseClass string
+
+const (
+	LicenseA1 LicenseClass = "A1" // up to 125 cc and 11 kW
+	LicenseA2 LicenseClass = "A2" // up to 35 kW
+	LicenseA  LicenseClass = "A"
+)
+
+// Transmission is the kind of gearbox of a motorcycle
+type Transmission string
+
+const (
+	TransmissionManual        Transmission = "manual"
+	TransmissionAutomatic     Transmission = "automatic"
+	TransmissionSemiAutomatic Transmission = "semi_automatic"
+)
+
+// MotorcycleSpecs is the technical data of a motorcycle, numbers left 0 are unknown
+type MotorcycleSpecs struct {
+	Brand         string
+	Model         string
+	ModelYear     uint32
+	EngineCC      uint32 // 0 for electric motorcycles
+	PowerKW       float64
+	WeightKG      float64 // wet weight
+	SeatHeightMM  uint32
+	FuelCapacityL float64
+	Transmission  Transmission
+	LicenseClass  LicenseClass
+	Colorways     []string
+}
+
+// Available is the number of units that can still be reserved
+func (p Product) Available() uint64 {
+	if p.Reserved > p.Stock {
+		return 0
+	}
+	return p.Stock - p.Reserved
+}
+
+// StockItem is the quantity of a product reserved, released or committed for an order
+type StockItem struct {
+	ProductID uint64
+	Quantity  uint64
+}
+
+// MaxStockQuantity is the largest quantity of a single stock change, far above any real order. Larger ones
+// would not fit the signed $inc applied to the stock.
+const MaxStockQuantity = math.MaxInt32
+
+type ProductFilter struct {
+	ID          *uint64
+	Name        *string
+	Category    *string
+	Categories  []string // any of them
+	Price       *float64
+	PriceMin    *float64
+	PriceMax    *float64
+	Stock       *uint64
+	InStockOnly bool // only products with available units
+
+	// motorcycle specs, products without specs never match these
+	Brand          *string
+	EngineCCMin    *uint32
+	EngineCCMax    *uint32
+	ModelYearMin   *uint32
+	ModelYearMax   *uint32
+	LicenseClasses []LicenseClass // any of them
+	Transmission   *Transmission
+}
+
+// ProductSortField is a field product listings can be ordered by
+type ProductSortField string
+
+const (
+	ProductSortPrice     ProductSortField = "price"
+	ProductSortName      ProductSortField = "name"
+	ProductSortCreatedAt ProductSortField = "created_at"
+	ProductSortStock     ProductSortField = "stock"
+)
+
+// ProductSort orders product listings, by id when Field is empty. Products with equal values are ordered by id.
+type ProductSort struct {
+	Field      ProductSortField
+	Descending bool
+}
+
+type ProductUpdateData struct {
+	ID          *uint64
+	Name        *string
+	Category    *string
+	Description *string
+	Price       *float64
+	Stock       *uint64
+	Specs       *MotorcycleSpecs // replaces all specs
+	UpdatedAt   *time.Time
+}
+
+// Weights of the product fields in search, a word found in the name counts the most
+const (
+	SearchWeightName        = 10
+	SearchWeightCategory    = 5
+	SearchWeightDescription = 1
+)
+
+// ProductSearch is a page of products matching a search query, best matches first
+type ProductSearch struct {
+	Products    []Product
+	Total       int
+	Approximate bool // nothing matched the words of the query exactly
+}
+
+// ProductCursor marks the last product of a page, the next page starts right after it. Only the id and the
+// value of the sort field are set.
+type ProductCursor struct {
+	Sort      ProductSort
 	ID        uint64
-	Name      string
-	Category  string
 	Price     float64
-	Stock     uint64
+	Name      string
 	CreatedAt time.Time
-	UpdatedAt time.Time
+	Stock     uint64
 }
-type ProductFilter struct {
-	ID       *uint64
-	Name     *string
-	Category *string
-	Price    *float64
-	Stock    *uint64
+
+// NewProductCursor builds the cursor pointing after the product in a listing ordered by sort
+func NewProductCursor(sort ProductSort, product Product) *ProductCursor {
+	cursor := &ProductCursor{Sort: sort, ID: product.ID}
+	switch sort.Field {
+	case ProductSortPrice:
+		cursor.Price = product.Price
+	case ProductSortName:
+		cursor.Name = product.Name
+	case ProductSortCreatedAt:
+		cursor.CreatedAt = product.CreatedAt
+	case ProductSortStock:
+		cursor.Stock = product.Stock
+	}
+	return cursor
 }
 
-type ProductUpdateData struct {
-	ID        *uint64
-	Name      *string
-	Category  *string
-	Price     *float64
-	Stock     *uint64
-	UpdatedAt *time.Time
+// ProductPage selects a page of a listing, by offset or, when After is set, right after a cursor
+type ProductPage struct {
+	Page      int64
+	Limit     int64
+	After     *ProductCursor
+	SkipTotal bool
+}
+
+// ProductList is a page of a product listing
+type ProductList struct {
+	Products []Product
+	Total    int            // 0 when the page skipped counting
+	Next     *ProductCursor // nil on the last page
 }
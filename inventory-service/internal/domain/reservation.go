@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+type ReservationStatus string
+
+const (
+	ReservationHeld      ReservationStatus = "held"
+	ReservationCommitted ReservationStatus = "committed"
+	ReservationReleased  ReservationStatus = "released"
+	ReservationExpired   ReservationStatus = "expired"
+)
+
+// Reservation is the hold an order has on stock, units of a held reservation are counted as reserved
+// on their products until it is committed, released or expires
+type Reservation struct {
+	OrderID   uint64
+	Items     []StockItem
+	Status    ReservationStatus
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
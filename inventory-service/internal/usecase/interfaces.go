@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	"time"
 )
 
 type auto_inc_Repo interface {
@@ -12,12 +13,42 @@ type product_Repo interface {
 	Create(ctx context.Context, client domain.Product) error
 	Update(ctx context.Context, filter domain.ProductFilter, update domain.ProductUpdateData) error
 	GetWithFilter(ctx context.Context, filter domain.ProductFilter) (domain.Product, error)
-	GetListWithFilter(ctx context.Context, filter domain.ProductFilter, page, limit int64) ([]domain.Product, int, error)
+	GetByIDs(ctx context.Context, ids []uint64) ([]domain.Product, error)
+	GetListWithFilter(ctx context.Context, filter domain.ProductFilter, sort domain.ProductSort, page domain.ProductPage) (domain.ProductList, error)
 	Delete(ctx context.Context, filter domain.ProductFilter) error
+	SearchText(ctx context.Context, query string, page, limit int64) ([]domain.Product, int, error)
+	FindByWordPrefixes(ctx context.Context, prefixes []string, limit int64) ([]domain.Product, error)
+	ReserveStock(ctx context.Context, items []domain.StockItem, now time.Time) error
+	ReleaseStock(ctx context.Context, items []domain.StockItem, now time.Time) error
+	CommitStock(ctx context.Context, items []domain.StockItem, now time.Time) error
+	ReturnStock(ctx context.Context, items []domain.StockItem, now time.Time) error
+}
+
+type reservation_Repo interface {
+	Create(ctx context.Context, reservation domain.Reservation) error
+	Commit(ctx context.Context, orderID uint64, now time.Time) (domain.Reservation, error)
+	Release(ctx context.Context, orderID uint64, now time.Time) (domain.Reservation, error)
+	Expire(ctx context.Context, now time.Time) (domain.Reservation, error)
+	Restore(ctx context.Context, orderID uint64, from domain.ReservationStatus, now time.Time) error
+}
+
+type processed_order_Repo interface {
+	Create(ctx context.Context, order domain.ProcessedOrder) error
+	Get(ctx context.Context, orderID uint64) (domain.ProcessedOrder, error)
+	Reject(ctx context.Context, orderID uint64, reason, message string) error
+	Restock(ctx context.Context, orderID uint64) (domain.ProcessedOrder, error)
+	UndoRestock(ctx context.Context, orderID uint64) error
+	Delete(ctx context.Context, orderID uint64) error
+}
+
+// transactor runs a function whose writes have to be applied together
+type transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type ProductCache interface {
 	Get(ctx context.Context, productID uint64) (domain.Product, error)
+	GetMany(ctx context.Context, productIDs []uint64) (map[uint64]domain.Product, error)
 	Set(ctx context.Context, product domain.Product) error
 	SetMany(ctx context.Context, products []domain.Product) error
 	Delete(ctx context.Context, productID uint64) error
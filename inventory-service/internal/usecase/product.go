@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"github.com/BeksultanSE/Assignment1-inventory/internal/adapter/mongo"
 	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
 	"log"
@@ -9,16 +10,22 @@ import (
 )
 
 type Product struct {
-	aiRepo auto_inc_Repo
-	repo   product_Repo
-	cache  ProductCache
+	aiRepo          auto_inc_Repo
+	repo            product_Repo
+	processedRepo   processed_order_Repo
+	reservationRepo reservation_Repo
+	tx              transactor
+	cache           ProductCache
 }
 
-func NewProduct(aiRepo auto_inc_Repo, repo product_Repo, cache ProductCache) *Product {
+func NewProduct(aiRepo auto_inc_Repo, repo product_Repo, processedRepo processed_order_Repo, reservationRepo reservation_Repo, tx transactor, cache ProductCache) *Product {
 	return &Product{
-		aiRepo: aiRepo,
-		repo:   repo,
-		cache:  cache,
+		aiRepo:          aiRepo,
+		repo:            repo,
+		processedRepo:   processedRepo,
+		reservationRepo: reservationRepo,
+		tx:              tx,
+		cache:           cache,
 	}
 }
 
@@ -59,16 +66,68 @@ func (p *Product) Get(ctx context.Context, pf domain.ProductFilter) (domain.Prod
 	return product, nil
 }
 
-func (p *Product) GetAll(ctx context.Context, pf domain.ProductFilter, page, limit int64) ([]domain.Product, int, error) {
-	products, totalCount, err := p.repo.GetListWithFilter(ctx, pf, page, limit)
+// GetMany returns the products with the given ids in the order they were asked for, each once, and the ids
+// that have no product. Cached products are read in one round trip, the rest with a single query.
+func (p *Product) GetMany(ctx context.Context, ids []uint64) ([]domain.Product, []uint64, error) {
+	unique := make([]uint64, 0, len(ids))
+	seen := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	found, err := p.cache.GetMany(ctx, unique)
+	if err != nil {
+		log.Printf("Failed to read cached products: %v", err)
+		found = make(map[uint64]domain.Product, len(unique))
+	}
+
+	var misses []uint64
+	for _, id := range unique {
+		if _, ok := found[id]; !ok {
+			misses = append(misses, id)
+		}
+	}
+
+	if len(misses) > 0 {
+		products, err := p.repo.GetByIDs(ctx, misses)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, product := range products {
+			found[product.ID] = product
+		}
+
+		//caching
+		if err = p.cache.SetMany(ctx, products); err != nil {
+			log.Printf("Failed to cache products: %v", err)
+		}
+	}
+
+	products := make([]domain.Product, 0, len(unique))
+	var missing []uint64
+	for _, id := range unique {
+		if product, ok := found[id]; ok {
+			products = append(products, product)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return products, missing, nil
+}
+
+func (p *Product) GetAll(ctx context.Context, pf domain.ProductFilter, sort domain.ProductSort, page domain.ProductPage) (domain.ProductList, error) {
+	list, err := p.repo.GetListWithFilter(ctx, pf, sort, page)
 	if err != nil {
-		return nil, 0, err
+		return domain.ProductList{}, err
 	}
-	return products, totalCount, nil
+	return list, nil
 }
 
 func (p *Product) Update(ctx context.Context, filter domain.ProductFilter, updated domain.ProductUpdateData) error {
-	if *updated.Stock < uint64(0) {
+	if updated.Stock != nil && *updated.Stock < uint64(0) {
 		return domain.ErrInsufficientStock
 	}
 	updated.UpdatedAt = func() *time.Time { t := time.Now(); return &t }()
@@ -98,3 +157,172 @@ func (p *Product) Delete(ctx context.Context, filter domain.ProductFilter) error
 
 	return nil
 }
+
+// DeductOrderStock commits the units the order has held since checkout. Orders without a hold, e.g. because it
+// ran out before the event was processed, have their items reserved and committed right away.
+// The order is recorded as processed in the same write as the stock change,
+// domain.ErrOrderAlreadyProcessed is returned for an order that has been handled before and nothing is changed.
+// An order that does not fit the stock is recorded as rejected and returned with its reason.
+// Without transactions (standalone mongo) every step that fails is compensated, so a failed deduction leaves
+// neither held stock nor a record behind and the event can be processed again.
+func (p *Product) DeductOrderStock(ctx context.Context, orderID uint64, items []domain.StockItem) (domain.ProcessedOrder, error) {
+	var processed domain.ProcessedOrder
+	err := p.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		// the transaction may be retried, every attempt starts from a fresh record
+		processed = domain.ProcessedOrder{
+			OrderID:     orderID,
+			Status:      domain.ProcessedOrderDeducted,
+			Items:       items,
+			ProcessedAt: time.Now(),
+		}
+
+		// recorded first, so a duplicate is rejected before any stock is touched
+		if err := p.processedRepo.Create(ctx, processed); err != nil {
+			return err
+		}
+
+		committed, err := p.commitHeld(ctx, orderID, processed.ProcessedAt)
+		if err == nil && !committed {
+			err = p.repo.ReserveStock(ctx, items, processed.ProcessedAt)
+			if err == nil {
+				if err = p.repo.CommitStock(ctx, items, processed.ProcessedAt); err != nil {
+					p.releaseHeld(ctx, orderID, items, processed.ProcessedAt)
+				}
+			}
+		}
+		switch {
+		case err == nil:
+			return nil
+		case errors.Is(err, domain.ErrInsufficientStock), errors.Is(err, domain.ErrProductNotFound):
+			processed.Status = domain.ProcessedOrderRejected
+			processed.Reason = domain.RejectReasonInsufficientStock
+			if errors.Is(err, domain.ErrProductNotFound) {
+				processed.Reason = domain.RejectReasonProductNotFound
+			}
+			processed.Message = err.Error()
+			if errReject := p.processedRepo.Reject(ctx, orderID, processed.Reason, processed.Message); errReject != nil {
+				p.forgetProcessed(ctx, orderID)
+				return errReject
+			}
+			return nil
+		default:
+			p.forgetProcessed(ctx, orderID)
+			return err
+		}
+	})
+	if err != nil {
+		return domain.ProcessedOrder{}, err
+	}
+
+	if processed.Status == domain.ProcessedOrderDeducted {
+		invalidate(ctx, p.cache, items)
+	}
+	return processed, nil
+}
+
+// commitHeld commits the units held for the order at checkout. It reports false when the order holds nothing,
+// a hold that ran out but has not been swept yet is left to the sweeper.
+func (p *Product) commitHeld(ctx context.Context, orderID uint64, now time.Time) (bool, error) {
+	reservation, err := p.reservationRepo.Commit(ctx, orderID, now)
+	if errors.Is(err, domain.ErrReservationNotFound) || errors.Is(err, domain.ErrReservationExpired) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err = p.repo.CommitStock(ctx, reservation.Items, now); err != nil {
+		if errRestore := p.reservationRepo.Restore(context.WithoutCancel(ctx), orderID, domain.ReservationCommitted, time.Now()); errRestore != nil {
+			log.Printf("Failed to restore reservation of order %d: %v", orderID, errRestore)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// releaseHeld makes the items reserved for an order that could not be committed available again
+func (p *Product) releaseHeld(ctx context.Context, orderID uint64, items []domain.StockItem, now time.Time) {
+	if err := p.repo.ReleaseStock(context.WithoutCancel(ctx), items, now); err != nil {
+		log.Printf("Failed to release stock held for order %d: %v", orderID, err)
+	}
+}
+
+// forgetProcessed deletes the record of an order whose stock could not be handled, so the event can be
+// processed again when it is redelivered
+func (p *Product) forgetProcessed(ctx context.Context, orderID uint64) {
+	if err := p.processedRepo.Delete(context.WithoutCancel(ctx), orderID); err != nil {
+		log.Printf("Failed to delete processed order %d: %v", orderID, err)
+	}
+}
+
+// RestockOrder returns the deducted stock of an order to the warehouse, e.g. when the order has been cancelled
+// before inventory confirmed it. Products deleted in the meantime are skipped. It reports false when there is
+// nothing to return: the order was rejected, never processed or has been restocked already.
+func (p *Product) RestockOrder(ctx context.Context, orderID uint64) (bool, error) {
+	var items []domain.StockItem
+	err := p.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		items = nil
+		processed, err := p.processedRepo.Restock(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		products, err := p.repo.GetByIDs(ctx, stockItemIDs(processed.Items))
+		if err != nil {
+			return p.undoRestock(ctx, orderID, err)
+		}
+		existing := make(map[uint64]bool, len(products))
+		for _, product := range products {
+			existing[product.ID] = true
+		}
+		for _, item := range processed.Items {
+			if existing[item.ProductID] {
+				items = append(items, item)
+			}
+		}
+
+		if err = p.repo.ReturnStock(ctx, items, time.Now()); err != nil {
+			return p.undoRestock(ctx, orderID, err)
+		}
+		return nil
+	})
+	if errors.Is(err, domain.ErrOrderNotDeducted) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	invalidate(ctx, p.cache, items)
+	return true, nil
+}
+
+// undoRestock lets the restock of the order be tried again after it failed with cause
+func (p *Product) undoRestock(ctx context.Context, orderID uint64, cause error) error {
+	if err := p.processedRepo.UndoRestock(context.WithoutCancel(ctx), orderID); err != nil {
+		log.Printf("Failed to undo restock of order %d: %v", orderID, err)
+	}
+	return cause
+}
+
+func stockItemIDs(items []domain.StockItem) []uint64 {
+	ids := make([]uint64, len(items))
+	for i, item := range items {
+		ids[i] = item.ProductID
+	}
+	return ids
+}
+
+// GetProcessedOrder returns how the stock of an order has been handled
+func (p *Product) GetProcessedOrder(ctx context.Context, orderID uint64) (domain.ProcessedOrder, error) {
+	return p.processedRepo.Get(ctx, orderID)
+}
+
+// invalidate drops the cached copies of products whose stock has changed
+func invalidate(ctx context.Context, cache ProductCache, items []domain.StockItem) {
+	for _, item := range items {
+		if err := cache.Delete(ctx, item.ProductID); err != nil {
+			log.Printf("Failed to invalidate cache for product %d: %v", item.ProductID, err)
+		}
+	}
+}
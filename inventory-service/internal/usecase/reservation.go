@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	"log"
+	"time"
+)
+
+// Reservation holds stock for orders for a limited time. Held units stay in stock but are no longer
+// available, they are committed when the order is fulfilled or become available again when it is
+// cancelled or the hold runs out.
+type Reservation struct {
+	repo        reservation_Repo
+	productRepo product_Repo
+	cache       ProductCache
+	holdTTL     time.Duration
+	maxHoldTTL  time.Duration
+}
+
+func NewReservation(repo reservation_Repo, productRepo product_Repo, cache ProductCache, holdTTL, maxHoldTTL time.Duration) *Reservation {
+	return &Reservation{
+		repo:        repo,
+		productRepo: productRepo,
+		cache:       cache,
+		holdTTL:     holdTTL,
+		maxHoldTTL:  maxHoldTTL,
+	}
+}
+
+// Reserve holds the items of an order, all of them or none. The hold lasts for the given duration, the
+// configured default is used when it is zero and it is capped at the configured maximum.
+func (r *Reservation) Reserve(ctx context.Context, orderID uint64, items []domain.StockItem, hold time.Duration) (domain.Reservation, error) {
+	if hold <= 0 {
+		hold = r.holdTTL
+	}
+	if hold > r.maxHoldTTL {
+		hold = r.maxHoldTTL
+	}
+
+	now := time.Now()
+	reservation := domain.Reservation{
+		OrderID:   orderID,
+		Items:     items,
+		Status:    domain.ReservationHeld,
+		ExpiresAt: now.Add(hold),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := r.productRepo.ReserveStock(ctx, items, now); err != nil {
+		return domain.Reservation{}, err
+	}
+	defer invalidate(ctx, r.cache, items)
+
+	if err := r.repo.Create(ctx, reservation); err != nil {
+		// without the reservation nothing would ever release the units again
+		if errRelease := r.productRepo.ReleaseStock(context.WithoutCancel(ctx), items, time.Now()); errRelease != nil {
+			log.Printf("Failed to release stock of order %d: %v", orderID, errRelease)
+		}
+		return domain.Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// Release makes the units held by an order available again, e.g. when the order is cancelled. A hold that
+// has already been expired by the sweeper has given its units back, so there is nothing left to do.
+func (r *Reservation) Release(ctx context.Context, orderID uint64) error {
+	reservation, err := r.repo.Release(ctx, orderID, time.Now())
+	if err != nil {
+		if errors.Is(err, domain.ErrReservationExpired) {
+			return nil
+		}
+		return err
+	}
+
+	return r.applyClaimed(ctx, reservation, domain.ReservationReleased, r.productRepo.ReleaseStock)
+}
+
+// Commit finalizes the hold of an order, its units leave the inventory. Holds that ran out cannot be committed.
+func (r *Reservation) Commit(ctx context.Context, orderID uint64) error {
+	reservation, err := r.repo.Commit(ctx, orderID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return r.applyClaimed(ctx, reservation, domain.ReservationCommitted, r.productRepo.CommitStock)
+}
+
+// ReleaseExpired returns the units of every hold that ran out to available stock and reports how many holds
+// have been released
+func (r *Reservation) ReleaseExpired(ctx context.Context) (int, error) {
+	released := 0
+	for {
+		reservation, err := r.repo.Expire(ctx, time.Now())
+		if err != nil {
+			if errors.Is(err, domain.ErrReservationNotFound) {
+				return released, nil
+			}
+			return released, err
+		}
+
+		if err = r.applyClaimed(ctx, reservation, domain.ReservationExpired, r.productRepo.ReleaseStock); err != nil {
+			return released, err
+		}
+		released++
+	}
+}
+
+// applyClaimed changes the stock of a reservation that has just been moved to the given status. The
+// reservation is put back on hold if that fails, so the change can be retried.
+func (r *Reservation) applyClaimed(ctx context.Context, reservation domain.Reservation, status domain.ReservationStatus,
+	change func(ctx context.Context, items []domain.StockItem, now time.Time) error) error {
+	if err := change(ctx, reservation.Items, time.Now()); err != nil {
+		if errRestore := r.repo.Restore(context.WithoutCancel(ctx), reservation.OrderID, status, time.Now()); errRestore != nil {
+			log.Printf("Failed to restore reservation of order %d: %v", reservation.OrderID, errRestore)
+		}
+		return err
+	}
+
+	invalidate(ctx, r.cache, reservation.Items)
+	return nil
+}
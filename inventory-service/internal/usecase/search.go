@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-inventory/internal/domain"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// searchCandidateLimit caps the products scored by approximate search
+	searchCandidateLimit = 500
+	// searchPrefixLength is how many leading letters of a word have to be typed right for approximate search
+	searchPrefixLength = 2
+)
+
+// Search finds products by the words of the query, best matches first. Whole words are looked up in the text
+// index. When none of them matches, products with words starting like the query words or a few typos away
+// from them are returned instead, e.g. "ninj" or "nimja" both find "Kawasaki Ninja 650".
+func (p *Product) Search(ctx context.Context, query string, page, limit int64) (domain.ProductSearch, error) {
+	offset, ok := pageOffset(page, limit)
+	if !ok {
+		return domain.ProductSearch{}, fmt.Errorf("page %d with %d products per page is out of range", page, limit)
+	}
+
+	products, total, err := p.repo.SearchText(ctx, query, page, limit)
+	if err != nil {
+		return domain.ProductSearch{}, err
+	}
+	if total > 0 {
+		return domain.ProductSearch{Products: products, Total: total}, nil
+	}
+
+	terms := searchTerms(query)
+	prefixes := make([]string, 0, len(terms))
+	for _, term := range terms {
+		prefixes = append(prefixes, string([]rune(term)[:min(searchPrefixLength, len([]rune(term)))]))
+	}
+
+	candidates, err := p.repo.FindByWordPrefixes(ctx, prefixes, searchCandidateLimit)
+	if err != nil {
+		return domain.ProductSearch{}, err
+	}
+
+	type match struct {
+		product domain.Product
+		score   float64
+	}
+	matches := make([]match, 0, len(candidates))
+	for _, candidate := range candidates {
+		if score := approximateScore(terms, candidate); score > 0 {
+			matches = append(matches, match{product: candidate, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := domain.ProductSearch{Total: len(matches), Approximate: true}
+	if offset < int64(len(matches)) {
+		for _, m := range matches[offset:min(int64(len(matches)), offset+limit)] {
+			result.Products = append(result.Products, m.product)
+		}
+	}
+	return result, nil
+}
+
+// pageOffset is the number of results before the page, false when the page does not exist or the offset
+// does not fit an int64
+func pageOffset(page, limit int64) (int64, bool) {
+	if page < 1 || limit < 1 || page-1 > math.MaxInt64/limit {
+		return 0, false
+	}
+	return (page - 1) * limit, true
+}
+
+// searchTerms splits the query into lowercase words
+func searchTerms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// approximateScore rates how well the product matches the terms. Every term adds the weight of the field it
+// is found in best, a full weight for a word starting with the term and less the more typos it takes.
+func approximateScore(terms []string, product domain.Product) float64 {
+	fields := []struct {
+		text   string
+		weight float64
+	}{
+		{product.Name, domain.SearchWeightName},
+		{product.Category, domain.SearchWeightCategory},
+		{product.Description, domain.SearchWeightDescription},
+	}
+
+	score := 0.0
+	for _, term := range terms {
+		best := 0.0
+		for _, field := range fields {
+			for _, word := range searchTerms(field.text) {
+				if s := termScore(term, word) * field.weight; s > best {
+					best = s
+				}
+			}
+		}
+		score += best
+	}
+	return score
+}
+
+// termScore is 1 when the word starts with the term and shrinks with the number of edits between them,
+// 0 once more edits are needed than the length of the term allows
+func termScore(term, word string) float64 {
+	if strings.HasPrefix(word, term) {
+		return 1
+	}
+
+	t, w := []rune(term), []rune(word)
+	allowed := maxTypos(len(t))
+	if allowed == 0 {
+		return 0
+	}
+
+	// the term may be the beginning of the word, so it is also compared to a prefix of the same length
+	edits := editDistance(t, w)
+	if len(w) > len(t) {
+		edits = min(edits, editDistance(t, w[:len(t)]))
+	}
+	if edits > allowed {
+		return 0
+	}
+	return 0.8 * (1 - float64(edits)/float64(len(t)+1))
+}
+
+// maxTypos is the number of edits tolerated for a term, short terms have to be typed right
+func maxTypos(length int) int {
+	switch {
+	case length <= 3:
+		return 0
+	case length <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
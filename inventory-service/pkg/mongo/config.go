@@ -21,5 +21,9 @@ func (m Config) genConnectURL() string {
 		url = fmt.Sprintf("mongodb://%s:%s@%s/?tls=%t&retryWrites=false", m.Username, m.Password, m.URI, m.TLSEnable)
 	}
 
+	if m.ReplicaSet != "" {
+		url += "&replicaSet=" + m.ReplicaSet
+	}
+
 	return url
 }
@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -14,6 +15,10 @@ import (
 type DB struct {
 	Conn   *mongo.Database
 	Client *mongo.Client
+
+	// transactions is set when the deployment is a replica set or a sharded cluster, standalone servers
+	// do not support them
+	transactions bool
 }
 
 // NewDB creates a connection to MongoDB and returns a DB struct.
@@ -42,6 +47,14 @@ func NewDB(ctx context.Context, cfg Config) (*DB, error) {
 		return nil, fmt.Errorf("failed to ping MongoDB: %w", err)
 	}
 
+	db.transactions, err = supportsTransactions(ctx, db.Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect MongoDB deployment: %w", err)
+	}
+	if !db.transactions {
+		log.Println("MongoDB is running standalone, multi-document writes are not transactional")
+	}
+
 	// Start a background reconnection routine
 	go db.reconnectOnFailure(ctx, cfg)
 
@@ -90,3 +103,37 @@ func (db *DB) Ping(ctx context.Context) error {
 	}
 	return nil
 }
+
+// WithTransaction runs fn in a transaction, so its writes are applied all together or not at all. Every
+// operation in fn has to use the context passed to it, and fn may run more than once when the transaction is
+// retried, so it must not depend on state left by an earlier attempt. On a standalone server, fn runs without a transaction
+// and has to compensate the writes it already made itself when it fails.
+func (db *DB) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !db.transactions {
+		return fn(ctx)
+	}
+
+	session, err := db.Client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start MongoDB session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
+
+// supportsTransactions tells whether the server is a replica set member or a mongos router
+func supportsTransactions(ctx context.Context, client *mongo.Client) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return false, err
+	}
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
+}
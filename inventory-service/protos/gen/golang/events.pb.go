@@ -133,6 +133,128 @@ func (x *OrderItemEvent) GetQuantity() uint64 {
 	return 0
 }
 
+// published to inventory.reserved once the stock of a created order has been deducted
+type InventoryReservedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ReservedAt    int64                  `protobuf:"varint,2,opt,name=reserved_at,json=reservedAt,proto3" json:"reserved_at,omitempty"` // unix seconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryReservedEvent) Reset() {
+	*x = InventoryReservedEvent{}
+	mi := &file_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryReservedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryReservedEvent) ProtoMessage() {}
+
+func (x *InventoryReservedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryReservedEvent.ProtoReflect.Descriptor instead.
+func (*InventoryReservedEvent) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InventoryReservedEvent) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *InventoryReservedEvent) GetReservedAt() int64 {
+	if x != nil {
+		return x.ReservedAt
+	}
+	return 0
+}
+
+// published to inventory.rejected when the stock of a created order could not be deducted, nothing has been changed
+type InventoryRejectedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // insufficient_stock or product_not_found
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	RejectedAt    int64                  `protobuf:"varint,4,opt,name=rejected_at,json=rejectedAt,proto3" json:"rejected_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryRejectedEvent) Reset() {
+	*x = InventoryRejectedEvent{}
+	mi := &file_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryRejectedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryRejectedEvent) ProtoMessage() {}
+
+func (x *InventoryRejectedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryRejectedEvent.ProtoReflect.Descriptor instead.
+func (*InventoryRejectedEvent) Descriptor() ([]byte, []int) {
+	return file_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *InventoryRejectedEvent) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *InventoryRejectedEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *InventoryRejectedEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *InventoryRejectedEvent) GetRejectedAt() int64 {
+	if x != nil {
+		return x.RejectedAt
+	}
+	return 0
+}
+
 var File_events_proto protoreflect.FileDescriptor
 
 const file_events_proto_rawDesc = "" +
@@ -145,7 +267,17 @@ const file_events_proto_rawDesc = "" +
 	"\x0eOrderItemEvent\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x04R\bquantityB\tZ\a./protob\x06proto3"
+	"\bquantity\x18\x02 \x01(\x04R\bquantity\"T\n" +
+	"\x16InventoryReservedEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x1f\n" +
+	"\vreserved_at\x18\x02 \x01(\x03R\n" +
+	"reservedAt\"\x86\x01\n" +
+	"\x16InventoryRejectedEvent\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1f\n" +
+	"\vrejected_at\x18\x04 \x01(\x03R\n" +
+	"rejectedAtB\tZ\a./protob\x06proto3"
 
 var (
 	file_events_proto_rawDescOnce sync.Once
@@ -159,10 +291,12 @@ func file_events_proto_rawDescGZIP() []byte {
 	return file_events_proto_rawDescData
 }
 
-var file_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_events_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_events_proto_goTypes = []any{
-	(*OrderCreatedEvent)(nil), // 0: events.OrderCreatedEvent
-	(*OrderItemEvent)(nil),    // 1: events.OrderItemEvent
+	(*OrderCreatedEvent)(nil),      // 0: events.OrderCreatedEvent
+	(*OrderItemEvent)(nil),         // 1: events.OrderItemEvent
+	(*InventoryReservedEvent)(nil), // 2: events.InventoryReservedEvent
+	(*InventoryRejectedEvent)(nil), // 3: events.InventoryRejectedEvent
 }
 var file_events_proto_depIdxs = []int32{
 	1, // 0: events.OrderCreatedEvent.items:type_name -> events.OrderItemEvent
@@ -184,7 +318,7 @@ func file_events_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_proto_rawDesc), len(file_events_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -27,6 +27,8 @@ type CreateProductRequest struct {
 	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
 	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
 	Stock         uint64                 `protobuf:"varint,4,opt,name=stock,proto3" json:"stock,omitempty"`
+	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Specs         *MotorcycleSpecs       `protobuf:"bytes,6,opt,name=specs,proto3" json:"specs,omitempty"` // left out for products that are not motorcycles, e.g. gear
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,6 +91,145 @@ func (x *CreateProductRequest) GetStock() uint64 {
 	return 0
 }
 
+func (x *CreateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetSpecs() *MotorcycleSpecs {
+	if x != nil {
+		return x.Specs
+	}
+	return nil
+}
+
+// MotorcycleSpecs is the technical data of a motorcycle, numbers left 0 are unknown
+type MotorcycleSpecs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brand         string                 `protobuf:"bytes,1,opt,name=brand,proto3" json:"brand,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	ModelYear     uint32                 `protobuf:"varint,3,opt,name=model_year,json=modelYear,proto3" json:"model_year,omitempty"`
+	EngineCc      uint32                 `protobuf:"varint,4,opt,name=engine_cc,json=engineCc,proto3" json:"engine_cc,omitempty"` // displacement in cubic centimetres, 0 for electric motorcycles
+	PowerKw       float64                `protobuf:"fixed64,5,opt,name=power_kw,json=powerKw,proto3" json:"power_kw,omitempty"`
+	WeightKg      float64                `protobuf:"fixed64,6,opt,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"` // wet weight
+	SeatHeightMm  uint32                 `protobuf:"varint,7,opt,name=seat_height_mm,json=seatHeightMm,proto3" json:"seat_height_mm,omitempty"`
+	FuelCapacityL float64                `protobuf:"fixed64,8,opt,name=fuel_capacity_l,json=fuelCapacityL,proto3" json:"fuel_capacity_l,omitempty"`
+	Transmission  string                 `protobuf:"bytes,9,opt,name=transmission,proto3" json:"transmission,omitempty"`                      // manual, automatic or semi_automatic
+	LicenseClass  string                 `protobuf:"bytes,10,opt,name=license_class,json=licenseClass,proto3" json:"license_class,omitempty"` // A1, A2 or A
+	Colorways     []string               `protobuf:"bytes,11,rep,name=colorways,proto3" json:"colorways,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MotorcycleSpecs) Reset() {
+	*x = MotorcycleSpecs{}
+	mi := &file_product_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MotorcycleSpecs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MotorcycleSpecs) ProtoMessage() {}
+
+func (x *MotorcycleSpecs) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MotorcycleSpecs.ProtoReflect.Descriptor instead.
+func (*MotorcycleSpecs) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MotorcycleSpecs) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *MotorcycleSpecs) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *MotorcycleSpecs) GetModelYear() uint32 {
+	if x != nil {
+		return x.ModelYear
+	}
+	return 0
+}
+
+func (x *MotorcycleSpecs) GetEngineCc() uint32 {
+	if x != nil {
+		return x.EngineCc
+	}
+	return 0
+}
+
+func (x *MotorcycleSpecs) GetPowerKw() float64 {
+	if x != nil {
+		return x.PowerKw
+	}
+	return 0
+}
+
+func (x *MotorcycleSpecs) GetWeightKg() float64 {
+	if x != nil {
+		return x.WeightKg
+	}
+	return 0
+}
+
+func (x *MotorcycleSpecs) GetSeatHeightMm() uint32 {
+	if x != nil {
+		return x.SeatHeightMm
+	}
+	return 0
+}
+
+func (x *MotorcycleSpecs) GetFuelCapacityL() float64 {
+	if x != nil {
+		return x.FuelCapacityL
+	}
+	return 0
+}
+
+func (x *MotorcycleSpecs) GetTransmission() string {
+	if x != nil {
+		return x.Transmission
+	}
+	return ""
+}
+
+func (x *MotorcycleSpecs) GetLicenseClass() string {
+	if x != nil {
+		return x.LicenseClass
+	}
+	return ""
+}
+
+func (x *MotorcycleSpecs) GetColorways() []string {
+	if x != nil {
+		return x.Colorways
+	}
+	return nil
+}
+
 type GetProductRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
@@ -98,7 +239,7 @@ type GetProductRequest struct {
 
 func (x *GetProductRequest) Reset() {
 	*x = GetProductRequest{}
-	mi := &file_product_proto_msgTypes[1]
+	mi := &file_product_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -110,7 +251,7 @@ func (x *GetProductRequest) String() string {
 func (*GetProductRequest) ProtoMessage() {}
 
 func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[1]
+	mi := &file_product_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -123,7 +264,7 @@ func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
 func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{1}
+	return file_product_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetProductRequest) GetProductId() uint64 {
@@ -133,6 +274,103 @@ func (x *GetProductRequest) GetProductId() uint64 {
 	return 0
 }
 
+// BatchGetProductsRequest looks up several products in one call, ids may repeat
+type BatchGetProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds    []uint64               `protobuf:"varint,1,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetProductsRequest) Reset() {
+	*x = BatchGetProductsRequest{}
+	mi := &file_product_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetProductsRequest) ProtoMessage() {}
+
+func (x *BatchGetProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetProductsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetProductsRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchGetProductsRequest) GetProductIds() []uint64 {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+type BatchGetProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*ProductResponse     `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`                               // in the order of the request, each product once
+	MissingIds    []uint64               `protobuf:"varint,2,rep,packed,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"` // requested ids without a product
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetProductsResponse) Reset() {
+	*x = BatchGetProductsResponse{}
+	mi := &file_product_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetProductsResponse) ProtoMessage() {}
+
+func (x *BatchGetProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetProductsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetProductsResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetProductsResponse) GetProducts() []*ProductResponse {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *BatchGetProductsResponse) GetMissingIds() []uint64 {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
 type UpdateProductRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
@@ -140,13 +378,15 @@ type UpdateProductRequest struct {
 	Category      *string                `protobuf:"bytes,3,opt,name=category,proto3,oneof" json:"category,omitempty"`
 	Price         *float64               `protobuf:"fixed64,4,opt,name=price,proto3,oneof" json:"price,omitempty"`
 	Stock         *uint64                `protobuf:"varint,5,opt,name=stock,proto3,oneof" json:"stock,omitempty"`
+	Description   *string                `protobuf:"bytes,6,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Specs         *MotorcycleSpecs       `protobuf:"bytes,7,opt,name=specs,proto3" json:"specs,omitempty"` // replaces all specs when set
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateProductRequest) Reset() {
 	*x = UpdateProductRequest{}
-	mi := &file_product_proto_msgTypes[2]
+	mi := &file_product_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -158,7 +398,7 @@ func (x *UpdateProductRequest) String() string {
 func (*UpdateProductRequest) ProtoMessage() {}
 
 func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[2]
+	mi := &file_product_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -171,7 +411,7 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
 func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{2}
+	return file_product_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UpdateProductRequest) GetProductId() uint64 {
@@ -209,21 +449,50 @@ func (x *UpdateProductRequest) GetStock() uint64 {
 	return 0
 }
 
+func (x *UpdateProductRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetSpecs() *MotorcycleSpecs {
+	if x != nil {
+		return x.Specs
+	}
+	return nil
+}
+
 type ListProductsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          *string                `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
-	Category      *string                `protobuf:"bytes,2,opt,name=category,proto3,oneof" json:"category,omitempty"`
-	Price         *float64               `protobuf:"fixed64,3,opt,name=price,proto3,oneof" json:"price,omitempty"`
-	Stock         *uint64                `protobuf:"varint,4,opt,name=stock,proto3,oneof" json:"stock,omitempty"`
-	Page          int64                  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int64                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           *string                `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Category       *string                `protobuf:"bytes,2,opt,name=category,proto3,oneof" json:"category,omitempty"`
+	Price          *float64               `protobuf:"fixed64,3,opt,name=price,proto3,oneof" json:"price,omitempty"`
+	Stock          *uint64                `protobuf:"varint,4,opt,name=stock,proto3,oneof" json:"stock,omitempty"`
+	Page           int64                  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	Limit          int64                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	PriceMin       *float64               `protobuf:"fixed64,7,opt,name=price_min,json=priceMin,proto3,oneof" json:"price_min,omitempty"`
+	PriceMax       *float64               `protobuf:"fixed64,8,opt,name=price_max,json=priceMax,proto3,oneof" json:"price_max,omitempty"`
+	InStockOnly    bool                   `protobuf:"varint,9,opt,name=in_stock_only,json=inStockOnly,proto3" json:"in_stock_only,omitempty"` // only products with available units
+	Categories     []string               `protobuf:"bytes,10,rep,name=categories,proto3" json:"categories,omitempty"`                        // products in any of the categories
+	SortBy         string                 `protobuf:"bytes,11,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`                  // price, name, created_at or stock, by id when empty
+	SortOrder      string                 `protobuf:"bytes,12,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`         // asc (default) or desc
+	PageToken      string                 `protobuf:"bytes,13,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`         // next_page_token of the previous page, page is ignored when set
+	SkipTotal      bool                   `protobuf:"varint,14,opt,name=skip_total,json=skipTotal,proto3" json:"skip_total,omitempty"`        // total is not counted and left 0
+	Brand          *string                `protobuf:"bytes,15,opt,name=brand,proto3,oneof" json:"brand,omitempty"`
+	EngineCcMin    *uint32                `protobuf:"varint,16,opt,name=engine_cc_min,json=engineCcMin,proto3,oneof" json:"engine_cc_min,omitempty"`
+	EngineCcMax    *uint32                `protobuf:"varint,17,opt,name=engine_cc_max,json=engineCcMax,proto3,oneof" json:"engine_cc_max,omitempty"`
+	ModelYearMin   *uint32                `protobuf:"varint,18,opt,name=model_year_min,json=modelYearMin,proto3,oneof" json:"model_year_min,omitempty"`
+	ModelYearMax   *uint32                `protobuf:"varint,19,opt,name=model_year_max,json=modelYearMax,proto3,oneof" json:"model_year_max,omitempty"`
+	LicenseClasses []string               `protobuf:"bytes,20,rep,name=license_classes,json=licenseClasses,proto3" json:"license_classes,omitempty"` // motorcycles of any of the license classes
+	Transmission   *string                `protobuf:"bytes,21,opt,name=transmission,proto3,oneof" json:"transmission,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ListProductsRequest) Reset() {
 	*x = ListProductsRequest{}
-	mi := &file_product_proto_msgTypes[3]
+	mi := &file_product_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -235,7 +504,7 @@ func (x *ListProductsRequest) String() string {
 func (*ListProductsRequest) ProtoMessage() {}
 
 func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[3]
+	mi := &file_product_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -248,7 +517,7 @@ func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
 func (*ListProductsRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{3}
+	return file_product_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListProductsRequest) GetName() string {
@@ -293,6 +562,111 @@ func (x *ListProductsRequest) GetLimit() int64 {
 	return 0
 }
 
+func (x *ListProductsRequest) GetPriceMin() float64 {
+	if x != nil && x.PriceMin != nil {
+		return *x.PriceMin
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetPriceMax() float64 {
+	if x != nil && x.PriceMax != nil {
+		return *x.PriceMax
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetInStockOnly() bool {
+	if x != nil {
+		return x.InStockOnly
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSkipTotal() bool {
+	if x != nil {
+		return x.SkipTotal
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetBrand() string {
+	if x != nil && x.Brand != nil {
+		return *x.Brand
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetEngineCcMin() uint32 {
+	if x != nil && x.EngineCcMin != nil {
+		return *x.EngineCcMin
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetEngineCcMax() uint32 {
+	if x != nil && x.EngineCcMax != nil {
+		return *x.EngineCcMax
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetModelYearMin() uint32 {
+	if x != nil && x.ModelYearMin != nil {
+		return *x.ModelYearMin
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetModelYearMax() uint32 {
+	if x != nil && x.ModelYearMax != nil {
+		return *x.ModelYearMax
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetLicenseClasses() []string {
+	if x != nil {
+		return x.LicenseClasses
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetTransmission() string {
+	if x != nil && x.Transmission != nil {
+		return *x.Transmission
+	}
+	return ""
+}
+
 type DeleteProductRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
@@ -302,7 +676,7 @@ type DeleteProductRequest struct {
 
 func (x *DeleteProductRequest) Reset() {
 	*x = DeleteProductRequest{}
-	mi := &file_product_proto_msgTypes[4]
+	mi := &file_product_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -314,7 +688,7 @@ func (x *DeleteProductRequest) String() string {
 func (*DeleteProductRequest) ProtoMessage() {}
 
 func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[4]
+	mi := &file_product_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -327,7 +701,7 @@ func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
 func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{4}
+	return file_product_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteProductRequest) GetProductId() uint64 {
@@ -343,16 +717,20 @@ type ProductResponse struct {
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
 	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Stock         uint64                 `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
+	Stock         uint64                 `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"` // physical units in stock, held ones included
 	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Reserved      uint64                 `protobuf:"varint,8,opt,name=reserved,proto3" json:"reserved,omitempty"`   // units held for orders that have not been committed yet
+	Available     uint64                 `protobuf:"varint,9,opt,name=available,proto3" json:"available,omitempty"` // units that can still be reserved, stock - reserved
+	Description   string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	Specs         *MotorcycleSpecs       `protobuf:"bytes,11,opt,name=specs,proto3" json:"specs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProductResponse) Reset() {
 	*x = ProductResponse{}
-	mi := &file_product_proto_msgTypes[5]
+	mi := &file_product_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -364,7 +742,7 @@ func (x *ProductResponse) String() string {
 func (*ProductResponse) ProtoMessage() {}
 
 func (x *ProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[5]
+	mi := &file_product_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -377,7 +755,7 @@ func (x *ProductResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProductResponse.ProtoReflect.Descriptor instead.
 func (*ProductResponse) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{5}
+	return file_product_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ProductResponse) GetProductId() uint64 {
@@ -429,17 +807,46 @@ func (x *ProductResponse) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *ProductResponse) GetReserved() uint64 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *ProductResponse) GetAvailable() uint64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *ProductResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ProductResponse) GetSpecs() *MotorcycleSpecs {
+	if x != nil {
+		return x.Specs
+	}
+	return nil
+}
+
 type ListProductsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Products      []*ProductResponse     `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
 	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListProductsResponse) Reset() {
 	*x = ListProductsResponse{}
-	mi := &file_product_proto_msgTypes[6]
+	mi := &file_product_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -451,7 +858,7 @@ func (x *ListProductsResponse) String() string {
 func (*ListProductsResponse) ProtoMessage() {}
 
 func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[6]
+	mi := &file_product_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -464,7 +871,7 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
 func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{6}
+	return file_product_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListProductsResponse) GetProducts() []*ProductResponse {
@@ -481,6 +888,13 @@ func (x *ListProductsResponse) GetTotal() int64 {
 	return 0
 }
 
+func (x *ListProductsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type DeleteProductResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -490,7 +904,7 @@ type DeleteProductResponse struct {
 
 func (x *DeleteProductResponse) Reset() {
 	*x = DeleteProductResponse{}
-	mi := &file_product_proto_msgTypes[7]
+	mi := &file_product_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -502,7 +916,7 @@ func (x *DeleteProductResponse) String() string {
 func (*DeleteProductResponse) ProtoMessage() {}
 
 func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_proto_msgTypes[7]
+	mi := &file_product_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -515,7 +929,7 @@ func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
 func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
-	return file_product_proto_rawDescGZIP(), []int{7}
+	return file_product_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeleteProductResponse) GetMessage() string {
@@ -525,44 +939,392 @@ func (x *DeleteProductResponse) GetMessage() string {
 	return ""
 }
 
+// SearchProductsRequest looks for the words of the query in the name, category and description of products
+type SearchProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Page          int64                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsRequest) Reset() {
+	*x = SearchProductsRequest{}
+	mi := &file_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsRequest) ProtoMessage() {}
+
+func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
+func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchProductsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchProductsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// SearchProductsResponse lists the best matches first
+type SearchProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*ProductResponse     `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Approximate   bool                   `protobuf:"varint,3,opt,name=approximate,proto3" json:"approximate,omitempty"` // no word matched exactly, the products contain words starting like or close to the query
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsResponse) Reset() {
+	*x = SearchProductsResponse{}
+	mi := &file_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsResponse) ProtoMessage() {}
+
+func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsResponse.ProtoReflect.Descriptor instead.
+func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchProductsResponse) GetProducts() []*ProductResponse {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *SearchProductsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchProductsResponse) GetApproximate() bool {
+	if x != nil {
+		return x.Approximate
+	}
+	return false
+}
+
+// StockRequest changes the stock of all items of an order at once, either every item is applied or none.
+// ReserveStock holds the items for the order, ReleaseStock and CommitStock act on the items held by
+// the order and ignore the items of the request. RestockOrder returns the stock deducted for an order.created
+// event, e.g. when the order was cancelled before it got confirmed, and ignores the items of the request too.
+type StockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       uint64                 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*StockItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	HoldSeconds   uint32                 `protobuf:"varint,3,opt,name=hold_seconds,json=holdSeconds,proto3" json:"hold_seconds,omitempty"` // how long ReserveStock holds the items, the service default when 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockRequest) Reset() {
+	*x = StockRequest{}
+	mi := &file_product_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockRequest) ProtoMessage() {}
+
+func (x *StockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockRequest.ProtoReflect.Descriptor instead.
+func (*StockRequest) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StockRequest) GetOrderId() uint64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *StockRequest) GetItems() []*StockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *StockRequest) GetHoldSeconds() uint32 {
+	if x != nil {
+		return x.HoldSeconds
+	}
+	return 0
+}
+
+type StockItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     uint64                 `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      uint64                 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockItem) Reset() {
+	*x = StockItem{}
+	mi := &file_product_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockItem) ProtoMessage() {}
+
+func (x *StockItem) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockItem.ProtoReflect.Descriptor instead.
+func (*StockItem) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StockItem) GetProductId() uint64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StockItem) GetQuantity() uint64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type StockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // end of the hold in RFC 3339 (UTC), only set by ReserveStock
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockResponse) Reset() {
+	*x = StockResponse{}
+	mi := &file_product_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockResponse) ProtoMessage() {}
+
+func (x *StockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockResponse.ProtoReflect.Descriptor instead.
+func (*StockResponse) Descriptor() ([]byte, []int) {
+	return file_product_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StockResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StockResponse) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
 var File_product_proto protoreflect.FileDescriptor
 
 const file_product_proto_rawDesc = "" +
 	"\n" +
-	"\rproduct.proto\x12\tinventory\"r\n" +
+	"\rproduct.proto\x12\tinventory\"\xc6\x01\n" +
 	"\x14CreateProductRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
 	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x14\n" +
 	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x14\n" +
-	"\x05stock\x18\x04 \x01(\x04R\x05stock\"2\n" +
+	"\x05stock\x18\x04 \x01(\x04R\x05stock\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x120\n" +
+	"\x05specs\x18\x06 \x01(\v2\x1a.inventory.MotorcycleSpecsR\x05specs\"\xe6\x02\n" +
+	"\x0fMotorcycleSpecs\x12\x14\n" +
+	"\x05brand\x18\x01 \x01(\tR\x05brand\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12\x1d\n" +
+	"\n" +
+	"model_year\x18\x03 \x01(\rR\tmodelYear\x12\x1b\n" +
+	"\tengine_cc\x18\x04 \x01(\rR\bengineCc\x12\x19\n" +
+	"\bpower_kw\x18\x05 \x01(\x01R\apowerKw\x12\x1b\n" +
+	"\tweight_kg\x18\x06 \x01(\x01R\bweightKg\x12$\n" +
+	"\x0eseat_height_mm\x18\a \x01(\rR\fseatHeightMm\x12&\n" +
+	"\x0ffuel_capacity_l\x18\b \x01(\x01R\rfuelCapacityL\x12\"\n" +
+	"\ftransmission\x18\t \x01(\tR\ftransmission\x12#\n" +
+	"\rlicense_class\x18\n" +
+	" \x01(\tR\flicenseClass\x12\x1c\n" +
+	"\tcolorways\x18\v \x03(\tR\tcolorways\"2\n" +
 	"\x11GetProductRequest\x12\x1d\n" +
 	"\n" +
-	"product_id\x18\x01 \x01(\x04R\tproductId\"\xcf\x01\n" +
+	"product_id\x18\x01 \x01(\x04R\tproductId\":\n" +
+	"\x17BatchGetProductsRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\x04R\n" +
+	"productIds\"s\n" +
+	"\x18BatchGetProductsResponse\x126\n" +
+	"\bproducts\x18\x01 \x03(\v2\x1a.inventory.ProductResponseR\bproducts\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\x04R\n" +
+	"missingIds\"\xb8\x02\n" +
 	"\x14UpdateProductRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1f\n" +
 	"\bcategory\x18\x03 \x01(\tH\x01R\bcategory\x88\x01\x01\x12\x19\n" +
 	"\x05price\x18\x04 \x01(\x01H\x02R\x05price\x88\x01\x01\x12\x19\n" +
-	"\x05stock\x18\x05 \x01(\x04H\x03R\x05stock\x88\x01\x01B\a\n" +
+	"\x05stock\x18\x05 \x01(\x04H\x03R\x05stock\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x06 \x01(\tH\x04R\vdescription\x88\x01\x01\x120\n" +
+	"\x05specs\x18\a \x01(\v2\x1a.inventory.MotorcycleSpecsR\x05specsB\a\n" +
 	"\x05_nameB\v\n" +
 	"\t_categoryB\b\n" +
 	"\x06_priceB\b\n" +
-	"\x06_stock\"\xd9\x01\n" +
+	"\x06_stockB\x0e\n" +
+	"\f_description\"\xed\x06\n" +
 	"\x13ListProductsRequest\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1f\n" +
 	"\bcategory\x18\x02 \x01(\tH\x01R\bcategory\x88\x01\x01\x12\x19\n" +
 	"\x05price\x18\x03 \x01(\x01H\x02R\x05price\x88\x01\x01\x12\x19\n" +
 	"\x05stock\x18\x04 \x01(\x04H\x03R\x05stock\x88\x01\x01\x12\x12\n" +
 	"\x04page\x18\x05 \x01(\x03R\x04page\x12\x14\n" +
-	"\x05limit\x18\x06 \x01(\x03R\x05limitB\a\n" +
+	"\x05limit\x18\x06 \x01(\x03R\x05limit\x12 \n" +
+	"\tprice_min\x18\a \x01(\x01H\x04R\bpriceMin\x88\x01\x01\x12 \n" +
+	"\tprice_max\x18\b \x01(\x01H\x05R\bpriceMax\x88\x01\x01\x12\"\n" +
+	"\rin_stock_only\x18\t \x01(\bR\vinStockOnly\x12\x1e\n" +
+	"\n" +
+	"categories\x18\n" +
+	" \x03(\tR\n" +
+	"categories\x12\x17\n" +
+	"\asort_by\x18\v \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\f \x01(\tR\tsortOrder\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\r \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"skip_total\x18\x0e \x01(\bR\tskipTotal\x12\x19\n" +
+	"\x05brand\x18\x0f \x01(\tH\x06R\x05brand\x88\x01\x01\x12'\n" +
+	"\rengine_cc_min\x18\x10 \x01(\rH\aR\vengineCcMin\x88\x01\x01\x12'\n" +
+	"\rengine_cc_max\x18\x11 \x01(\rH\bR\vengineCcMax\x88\x01\x01\x12)\n" +
+	"\x0emodel_year_min\x18\x12 \x01(\rH\tR\fmodelYearMin\x88\x01\x01\x12)\n" +
+	"\x0emodel_year_max\x18\x13 \x01(\rH\n" +
+	"R\fmodelYearMax\x88\x01\x01\x12'\n" +
+	"\x0flicense_classes\x18\x14 \x03(\tR\x0elicenseClasses\x12'\n" +
+	"\ftransmission\x18\x15 \x01(\tH\vR\ftransmission\x88\x01\x01B\a\n" +
 	"\x05_nameB\v\n" +
 	"\t_categoryB\b\n" +
 	"\x06_priceB\b\n" +
-	"\x06_stock\"5\n" +
+	"\x06_stockB\f\n" +
+	"\n" +
+	"_price_minB\f\n" +
+	"\n" +
+	"_price_maxB\b\n" +
+	"\x06_brandB\x10\n" +
+	"\x0e_engine_cc_minB\x10\n" +
+	"\x0e_engine_cc_maxB\x11\n" +
+	"\x0f_model_year_minB\x11\n" +
+	"\x0f_model_year_maxB\x0f\n" +
+	"\r_transmission\"5\n" +
 	"\x14DeleteProductRequest\x12\x1d\n" +
 	"\n" +
-	"product_id\x18\x01 \x01(\x04R\tproductId\"\xca\x01\n" +
+	"product_id\x18\x01 \x01(\x04R\tproductId\"\xd8\x02\n" +
 	"\x0fProductResponse\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x12\n" +
@@ -573,19 +1335,51 @@ const file_product_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\tR\tupdatedAt\"d\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12\x1a\n" +
+	"\breserved\x18\b \x01(\x04R\breserved\x12\x1c\n" +
+	"\tavailable\x18\t \x01(\x04R\tavailable\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\x120\n" +
+	"\x05specs\x18\v \x01(\v2\x1a.inventory.MotorcycleSpecsR\x05specs\"\x8c\x01\n" +
 	"\x14ListProductsResponse\x126\n" +
 	"\bproducts\x18\x01 \x03(\v2\x1a.inventory.ProductResponseR\bproducts\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total\"1\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"1\n" +
 	"\x15DeleteProductResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2\x9b\x03\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"W\n" +
+	"\x15SearchProductsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\"\x88\x01\n" +
+	"\x16SearchProductsResponse\x126\n" +
+	"\bproducts\x18\x01 \x03(\v2\x1a.inventory.ProductResponseR\bproducts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12 \n" +
+	"\vapproximate\x18\x03 \x01(\bR\vapproximate\"x\n" +
+	"\fStockRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x04R\aorderId\x12*\n" +
+	"\x05items\x18\x02 \x03(\v2\x14.inventory.StockItemR\x05items\x12!\n" +
+	"\fhold_seconds\x18\x03 \x01(\rR\vholdSeconds\"F\n" +
+	"\tStockItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x04R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x04R\bquantity\"H\n" +
+	"\rStockResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt2\xda\x06\n" +
 	"\x10InventoryService\x12L\n" +
 	"\rCreateProduct\x12\x1f.inventory.CreateProductRequest\x1a\x1a.inventory.ProductResponse\x12F\n" +
 	"\n" +
-	"GetProduct\x12\x1c.inventory.GetProductRequest\x1a\x1a.inventory.ProductResponse\x12L\n" +
+	"GetProduct\x12\x1c.inventory.GetProductRequest\x1a\x1a.inventory.ProductResponse\x12[\n" +
+	"\x10BatchGetProducts\x12\".inventory.BatchGetProductsRequest\x1a#.inventory.BatchGetProductsResponse\x12L\n" +
 	"\rUpdateProduct\x12\x1f.inventory.UpdateProductRequest\x1a\x1a.inventory.ProductResponse\x12O\n" +
 	"\fListProducts\x12\x1e.inventory.ListProductsRequest\x1a\x1f.inventory.ListProductsResponse\x12R\n" +
-	"\rDeleteProduct\x12\x1f.inventory.DeleteProductRequest\x1a .inventory.DeleteProductResponseB\tZ\a./protob\x06proto3"
+	"\rDeleteProduct\x12\x1f.inventory.DeleteProductRequest\x1a .inventory.DeleteProductResponse\x12U\n" +
+	"\x0eSearchProducts\x12 .inventory.SearchProductsRequest\x1a!.inventory.SearchProductsResponse\x12A\n" +
+	"\fReserveStock\x12\x17.inventory.StockRequest\x1a\x18.inventory.StockResponse\x12A\n" +
+	"\fReleaseStock\x12\x17.inventory.StockRequest\x1a\x18.inventory.StockResponse\x12@\n" +
+	"\vCommitStock\x12\x17.inventory.StockRequest\x1a\x18.inventory.StockResponse\x12A\n" +
+	"\fRestockOrder\x12\x17.inventory.StockRequest\x1a\x18.inventory.StockResponseB\tZ\a./protob\x06proto3"
 
 var (
 	file_product_proto_rawDescOnce sync.Once
@@ -599,34 +1393,60 @@ func file_product_proto_rawDescGZIP() []byte {
 	return file_product_proto_rawDescData
 }
 
-var file_product_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_product_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_product_proto_goTypes = []any{
-	(*CreateProductRequest)(nil),  // 0: inventory.CreateProductRequest
-	(*GetProductRequest)(nil),     // 1: inventory.GetProductRequest
-	(*UpdateProductRequest)(nil),  // 2: inventory.UpdateProductRequest
-	(*ListProductsRequest)(nil),   // 3: inventory.ListProductsRequest
-	(*DeleteProductRequest)(nil),  // 4: inventory.DeleteProductRequest
-	(*ProductResponse)(nil),       // 5: inventory.ProductResponse
-	(*ListProductsResponse)(nil),  // 6: inventory.ListProductsResponse
-	(*DeleteProductResponse)(nil), // 7: inventory.DeleteProductResponse
+	(*CreateProductRequest)(nil),     // 0: inventory.CreateProductRequest
+	(*MotorcycleSpecs)(nil),          // 1: inventory.MotorcycleSpecs
+	(*GetProductRequest)(nil),        // 2: inventory.GetProductRequest
+	(*BatchGetProductsRequest)(nil),  // 3: inventory.BatchGetProductsRequest
+	(*BatchGetProductsResponse)(nil), // 4: inventory.BatchGetProductsResponse
+	(*UpdateProductRequest)(nil),     // 5: inventory.UpdateProductRequest
+	(*ListProductsRequest)(nil),      // 6: inventory.ListProductsRequest
+	(*DeleteProductRequest)(nil),     // 7: inventory.DeleteProductRequest
+	(*ProductResponse)(nil),          // 8: inventory.ProductResponse
+	(*ListProductsResponse)(nil),     // 9: inventory.ListProductsResponse
+	(*DeleteProductResponse)(nil),    // 10: inventory.DeleteProductResponse
+	(*SearchProductsRequest)(nil),    // 11: inventory.SearchProductsRequest
+	(*SearchProductsResponse)(nil),   // 12: inventory.SearchProductsResponse
+	(*StockRequest)(nil),             // 13: inventory.StockRequest
+	(*StockItem)(nil),                // 14: inventory.StockItem
+	(*StockResponse)(nil),            // 15: inventory.StockResponse
 }
 var file_product_proto_depIdxs = []int32{
-	5, // 0: inventory.ListProductsResponse.products:type_name -> inventory.ProductResponse
-	0, // 1: inventory.InventoryService.CreateProduct:input_type -> inventory.CreateProductRequest
-	1, // 2: inventory.InventoryService.GetProduct:input_type -> inventory.GetProductRequest
-	2, // 3: inventory.InventoryService.UpdateProduct:input_type -> inventory.UpdateProductRequest
-	3, // 4: inventory.InventoryService.ListProducts:input_type -> inventory.ListProductsRequest
-	4, // 5: inventory.InventoryService.DeleteProduct:input_type -> inventory.DeleteProductRequest
-	5, // 6: inventory.InventoryService.CreateProduct:output_type -> inventory.ProductResponse
-	5, // 7: inventory.InventoryService.GetProduct:output_type -> inventory.ProductResponse
-	5, // 8: inventory.InventoryService.UpdateProduct:output_type -> inventory.ProductResponse
-	6, // 9: inventory.InventoryService.ListProducts:output_type -> inventory.ListProductsResponse
-	7, // 10: inventory.InventoryService.DeleteProduct:output_type -> inventory.DeleteProductResponse
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1,  // 0: inventory.CreateProductRequest.specs:type_name -> inventory.MotorcycleSpecs
+	8,  // 1: inventory.BatchGetProductsResponse.products:type_name -> inventory.ProductResponse
+	1,  // 2: inventory.UpdateProductRequest.specs:type_name -> inventory.MotorcycleSpecs
+	1,  // 3: inventory.ProductResponse.specs:type_name -> inventory.MotorcycleSpecs
+	8,  // 4: inventory.ListProductsResponse.products:type_name -> inventory.ProductResponse
+	8,  // 5: inventory.SearchProductsResponse.products:type_name -> inventory.ProductResponse
+	14, // 6: inventory.StockRequest.items:type_name -> inventory.StockItem
+	0,  // 7: inventory.InventoryService.CreateProduct:input_type -> inventory.CreateProductRequest
+	2,  // 8: inventory.InventoryService.GetProduct:input_type -> inventory.GetProductRequest
+	3,  // 9: inventory.InventoryService.BatchGetProducts:input_type -> inventory.BatchGetProductsRequest
+	5,  // 10: inventory.InventoryService.UpdateProduct:input_type -> inventory.UpdateProductRequest
+	6,  // 11: inventory.InventoryService.ListProducts:input_type -> inventory.ListProductsRequest
+	7,  // 12: inventory.InventoryService.DeleteProduct:input_type -> inventory.DeleteProductRequest
+	11, // 13: inventory.InventoryService.SearchProducts:input_type -> inventory.SearchProductsRequest
+	13, // 14: inventory.InventoryService.ReserveStock:input_type -> inventory.StockRequest
+	13, // 15: inventory.InventoryService.ReleaseStock:input_type -> inventory.StockRequest
+	13, // 16: inventory.InventoryService.CommitStock:input_type -> inventory.StockRequest
+	13, // 17: inventory.InventoryService.RestockOrder:input_type -> inventory.StockRequest
+	8,  // 18: inventory.InventoryService.CreateProduct:output_type -> inventory.ProductResponse
+	8,  // 19: inventory.InventoryService.GetProduct:output_type -> inventory.ProductResponse
+	4,  // 20: inventory.InventoryService.BatchGetProducts:output_type -> inventory.BatchGetProductsResponse
+	8,  // 21: inventory.InventoryService.UpdateProduct:output_type -> inventory.ProductResponse
+	9,  // 22: inventory.InventoryService.ListProducts:output_type -> inventory.ListProductsResponse
+	10, // 23: inventory.InventoryService.DeleteProduct:output_type -> inventory.DeleteProductResponse
+	12, // 24: inventory.InventoryService.SearchProducts:output_type -> inventory.SearchProductsResponse
+	15, // 25: inventory.InventoryService.ReserveStock:output_type -> inventory.StockResponse
+	15, // 26: inventory.InventoryService.ReleaseStock:output_type -> inventory.StockResponse
+	15, // 27: inventory.InventoryService.CommitStock:output_type -> inventory.StockResponse
+	15, // 28: inventory.InventoryService.RestockOrder:output_type -> inventory.StockResponse
+	18, // [18:29] is the sub-list for method output_type
+	7,  // [7:18] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_product_proto_init() }
@@ -634,15 +1454,15 @@ func file_product_proto_init() {
 	if File_product_proto != nil {
 		return
 	}
-	file_product_proto_msgTypes[2].OneofWrappers = []any{}
-	file_product_proto_msgTypes[3].OneofWrappers = []any{}
+	file_product_proto_msgTypes[5].OneofWrappers = []any{}
+	file_product_proto_msgTypes[6].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_proto_rawDesc), len(file_product_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -17,6 +17,7 @@ type (
 		Version  string `env:"VERSION"`
 		Services Microservices
 		Brokers  []string `env:"BROKERS"`
+		Consumer Consumer
 	}
 
 	Server struct {
@@ -34,15 +35,26 @@ type (
 	}
 
 	GRPCServer struct {
-		Port    int           `env:"GRPC_PORT,required"`
-		Timeout time.Duration `env:"GRPC_TIMEOUT" envDefault:"30s"`
+		Port    int               `env:"GRPC_PORT,required"`
+		Timeout time.Duration     `env:"GRPC_TIMEOUT" envDefault:"30s"`
+		Callers map[string]string `env:"GRPC_CALLERS,required"` // service name to token of every service allowed to call, e.g. "api-gateway:<token>,user-service:<token>"
 	}
 
 	Microservices struct {
 		InventoryService ServiceConfig `envPrefix:"INVENTORY_SERVICE_"`
+		UserService      ServiceConfig `envPrefix:"USER_SERVICE_"`
+		Token            string        `env:"SERVICE_TOKEN,required"` // presented to the services called, has to be listed in their GRPC_CALLERS
 		//if you need other clients...
 	}
 
+	// Consumer configuration for processing inventory and user events
+	Consumer struct {
+		MaxRetries      int           `env:"CONSUMER_MAX_RETRIES" envDefault:"3"`
+		RetryBackoff    time.Duration `env:"CONSUMER_RETRY_BACKOFF" envDefault:"500ms"`
+		MaxRetryBackoff time.Duration `env:"CONSUMER_MAX_RETRY_BACKOFF" envDefault:"10s"`
+		DeadLetterTopic string        `env:"CONSUMER_DLQ_TOPIC" envDefault:"order-service.dlq"`
+	}
+
 	ServiceConfig struct {
 		Host string `env:"HOST,required"`
 		Port int    `env:"PORT,required"`
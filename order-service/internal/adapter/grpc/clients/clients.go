@@ -1,6 +1,7 @@
 package clients
 
 import (
+	"context"
 	"fmt"
 	"github.com/BeksultanSE/Assignment1-order/config"
 	proto "github.com/BeksultanSE/Assignment1-order/protos/gen/golang"
@@ -9,19 +10,25 @@ import (
 	"log"
 )
 
+// serviceName identifies this service to the services it calls
+const serviceName = "order-service"
+
 type Clients struct {
 	Inventory proto.InventoryServiceClient
+	User      proto.AuthClient
 	conns     []*grpc.ClientConn
 }
 
 func NewClients(cfg *config.Config) (*Clients, error) {
 	clients := &Clients{}
+	credentials := serviceCredentials{name: serviceName, token: cfg.Services.Token}
 
 	// Inventory Service Client
 	inventoryTarget := fmt.Sprintf("%s:%d", cfg.Services.InventoryService.Host, cfg.Services.InventoryService.Port)
 	inventoryConn, err := grpc.NewClient(
 		inventoryTarget,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(credentials),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to inventory service: %w", err)
@@ -29,6 +36,19 @@ func NewClients(cfg *config.Config) (*Clients, error) {
 	clients.Inventory = proto.NewInventoryServiceClient(inventoryConn)
 	clients.conns = append(clients.conns, inventoryConn)
 
+	// User Service Client
+	userTarget := fmt.Sprintf("%s:%d", cfg.Services.UserService.Host, cfg.Services.UserService.Port)
+	userConn, err := grpc.NewClient(
+		userTarget,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(credentials),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to user service: %w", err)
+	}
+	clients.User = proto.NewAuthClient(userConn)
+	clients.conns = append(clients.conns, userConn)
+
 	log.Println("Successfully initialized gRPC clients for all services")
 	return clients, nil
 }
@@ -40,3 +60,21 @@ func (c *Clients) Close() {
 		}
 	}
 }
+
+// serviceCredentials identify this service to the services it calls, they check the token against their GRPC_CALLERS
+type serviceCredentials struct {
+	name  string
+	token string
+}
+
+func (c serviceCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		"x-service-name":  c.name,
+		"x-service-token": c.token,
+	}, nil
+}
+
+// RequireTransportSecurity allows the token on the plaintext connections of the internal network
+func (c serviceCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -2,11 +2,17 @@ package clients
 
 import (
 	"context"
+	"fmt"
 	"github.com/BeksultanSE/Assignment1-order/internal/domain"
 	proto "github.com/BeksultanSE/Assignment1-order/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"time"
 )
 
+// batchGetMaxIDs is the most ids inventory-service accepts in one BatchGetProducts call
+const batchGetMaxIDs = 500
+
 type InventoryClient struct {
 	client proto.InventoryServiceClient
 }
@@ -24,15 +30,74 @@ func (c *InventoryClient) GetProduct(ctx context.Context, productID uint64) (dom
 		return domain.Product{}, err
 	}
 
+	return toDomainProduct(resp), nil
+}
+
+// GetProducts looks up the products in batches, so a whole page of orders needs a single call. Ids without a
+// product are left out of the map.
+func (c *InventoryClient) GetProducts(ctx context.Context, productIDs []uint64) (map[uint64]domain.Product, error) {
+	products := make(map[uint64]domain.Product, len(productIDs))
+	for start := 0; start < len(productIDs); start += batchGetMaxIDs {
+		end := min(start+batchGetMaxIDs, len(productIDs))
+		resp, err := c.client.BatchGetProducts(ctx, &proto.BatchGetProductsRequest{
+			ProductIds: productIDs[start:end],
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, product := range resp.Products {
+			products[product.ProductId] = toDomainProduct(product)
+		}
+	}
+	return products, nil
+}
+
+// ReserveStock holds the items for the order for inventory's default hold time, all of them or none
+func (c *InventoryClient) ReserveStock(ctx context.Context, orderID uint64, items []domain.OrderItem) error {
+	req := &proto.StockRequest{OrderId: orderID}
+	for _, item := range items {
+		req.Items = append(req.Items, &proto.StockItem{ProductId: item.ProductID, Quantity: item.Quantity})
+	}
+
+	_, err := c.client.ReserveStock(ctx, req)
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", domain.ErrInvalidQuantity, status.Convert(err).Message())
+	case codes.FailedPrecondition:
+		return fmt.Errorf("%w: %s", domain.ErrInsufficientStock, status.Convert(err).Message())
+	case codes.NotFound:
+		return fmt.Errorf("%w: %s", domain.ErrProductNotFound, status.Convert(err).Message())
+	default:
+		return err
+	}
+}
+
+// ReleaseStock makes the units held for the order available again
+func (c *InventoryClient) ReleaseStock(ctx context.Context, orderID uint64) error {
+	_, err := c.client.ReleaseStock(ctx, &proto.StockRequest{OrderId: orderID})
+	return err
+}
+
+// RestockOrder asks inventory to return the stock it deducted for the order, repeated calls return it once
+func (c *InventoryClient) RestockOrder(ctx context.Context, orderID uint64) error {
+	_, err := c.client.RestockOrder(ctx, &proto.StockRequest{OrderId: orderID})
+	return err
+}
+
+func toDomainProduct(resp *proto.ProductResponse) domain.Product {
 	return domain.Product{
 		ID:        resp.ProductId,
 		Name:      resp.Name,
 		Category:  resp.Category,
 		Price:     resp.Price,
 		Stock:     resp.Stock,
+		Available: resp.Available,
 		CreatedAt: parseTime(resp.CreatedAt),
 		UpdatedAt: parseTime(resp.UpdatedAt),
-	}, nil
+	}
 }
 
 func parseTime(timeStr string) time.Time {
@@ -0,0 +1,84 @@
+package clients
+
+import (
+	"context"
+	"github.com/BeksultanSE/Assignment1-order/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-order/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// forwardedMetadata is the caller identity attached by the api-gateway
+var forwardedMetadata = []string{"x-user-id", "x-user-role", "x-client-ip"}
+
+type UserClient struct {
+	client proto.AuthClient
+}
+
+func NewUserClient(client proto.AuthClient) *UserClient {
+	return &UserClient{client: client}
+}
+
+// GetAddress fetches an entry of the user's address book
+func (c *UserClient) GetAddress(ctx context.Context, userID, addressID uint64) (domain.Address, error) {
+	resp, err := c.client.GetAddress(forwardCaller(ctx), &proto.AddressRequest{
+		UserId:    userID,
+		AddressId: addressID,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return domain.Address{}, domain.ErrAddressNotFound
+		}
+		return domain.Address{}, err
+	}
+
+	return toDomainAddress(resp), nil
+}
+
+// GetDefaultAddress fetches the user's default shipping or billing address
+func (c *UserClient) GetDefaultAddress(ctx context.Context, userID uint64, addressType domain.AddressType) (domain.Address, error) {
+	resp, err := c.client.ListAddresses(forwardCaller(ctx), &proto.UserID{UserId: userID})
+	if err != nil {
+		return domain.Address{}, err
+	}
+
+	for _, address := range resp.Addresses {
+		if (addressType == domain.AddressShipping && address.DefaultShipping) ||
+			(addressType == domain.AddressBilling && address.DefaultBilling) {
+			return toDomainAddress(address), nil
+		}
+	}
+	return domain.Address{}, domain.ErrNoDefaultAddress
+}
+
+// forwardCaller passes the caller identity attached by the api-gateway on to user-service,
+// which only serves address books to their owners
+func forwardCaller(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	forwarded := metadata.MD{}
+	for _, key := range forwardedMetadata {
+		if values := md.Get(key); len(values) > 0 {
+			forwarded.Set(key, values...)
+		}
+	}
+	return metadata.NewOutgoingContext(ctx, forwarded)
+}
+
+func toDomainAddress(address *proto.Address) domain.Address {
+	return domain.Address{
+		ID:            address.AddressId,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		Region:        address.Region,
+		PostalCode:    address.PostalCode,
+		Country:       address.Country,
+	}
+}
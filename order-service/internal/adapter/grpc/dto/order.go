@@ -3,12 +3,16 @@ package dto
 import (
 	"github.com/BeksultanSE/Assignment1-order/internal/domain"
 	order "github.com/BeksultanSE/Assignment1-order/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"time"
 )
 
 type CreateOrderRequestDTO struct {
-	UserID uint64
-	Items  []CreateOrderItemDTO
+	UserID            uint64
+	Items             []CreateOrderItemDTO
+	ShippingAddressID uint64
+	BillingAddressID  uint64
 }
 
 type CreateOrderItemDTO struct {
@@ -25,13 +29,16 @@ type OrderItemDTO struct {
 }
 
 type OrderResponseDTO struct {
-	ID          uint64
-	UserID      uint64
-	Items       []OrderItemDTO
-	TotalAmount float64
-	Status      string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID              uint64
+	UserID          uint64
+	Items           []OrderItemDTO
+	TotalAmount     float64
+	Status          string
+	StatusReason    string
+	ShippingAddress domain.Address
+	BillingAddress  domain.Address
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 type GetOrderRequestDTO struct {
@@ -45,9 +52,11 @@ type UpdateOrderRequestDTO struct {
 }
 
 type ListOrdersRequestDTO struct {
-	UserID uint64
-	Page   int64
-	Limit  int64
+	UserID    uint64
+	Page      int64
+	Limit     int64
+	PageToken string
+	SkipTotal bool
 }
 
 func FromCreateOrderRequestProto(req *order.CreateOrderRequest) *CreateOrderRequestDTO {
@@ -59,8 +68,10 @@ func FromCreateOrderRequestProto(req *order.CreateOrderRequest) *CreateOrderRequ
 		}
 	}
 	return &CreateOrderRequestDTO{
-		UserID: req.UserId,
-		Items:  items,
+		UserID:            req.UserId,
+		Items:             items,
+		ShippingAddressID: req.ShippingAddressId,
+		BillingAddressID:  req.BillingAddressId,
 	}
 }
 
@@ -73,8 +84,10 @@ func (d *CreateOrderRequestDTO) ToDomainOrder() domain.Order {
 		}
 	}
 	return domain.Order{
-		UserID: d.UserID,
-		Items:  items,
+		UserID:          d.UserID,
+		Items:           items,
+		ShippingAddress: domain.Address{ID: d.ShippingAddressID},
+		BillingAddress:  domain.Address{ID: d.BillingAddressID},
 	}
 }
 
@@ -90,13 +103,16 @@ func FromDomainOrder(order domain.Order) *OrderResponseDTO {
 		}
 	}
 	return &OrderResponseDTO{
-		ID:          order.ID,
-		UserID:      order.UserID,
-		Items:       items,
-		TotalAmount: order.TotalAmount,
-		Status:      string(order.Status),
-		CreatedAt:   order.CreatedAt,
-		UpdatedAt:   order.UpdatedAt,
+		ID:              order.ID,
+		UserID:          order.UserID,
+		Items:           items,
+		TotalAmount:     order.TotalAmount,
+		Status:          string(order.Status),
+		StatusReason:    order.StatusReason,
+		ShippingAddress: order.ShippingAddress,
+		BillingAddress:  order.BillingAddress,
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
 	}
 }
 
@@ -112,13 +128,33 @@ func (d *OrderResponseDTO) ToProtoOrderResponse() *order.OrderResponse {
 		}
 	}
 	return &order.OrderResponse{
-		OrderId:     d.ID,
-		UserId:      d.UserID,
-		Items:       items,
-		TotalAmount: d.TotalAmount,
-		Status:      d.Status,
-		CreatedAt:   d.CreatedAt.String(),
-		UpdatedAt:   d.UpdatedAt.String(),
+		OrderId:         d.ID,
+		UserId:          d.UserID,
+		Items:           items,
+		TotalAmount:     d.TotalAmount,
+		Status:          d.Status,
+		StatusReason:    d.StatusReason,
+		ShippingAddress: toProtoOrderAddress(d.ShippingAddress),
+		BillingAddress:  toProtoOrderAddress(d.BillingAddress),
+		CreatedAt:       d.CreatedAt.String(),
+		UpdatedAt:       d.UpdatedAt.String(),
+	}
+}
+
+func toProtoOrderAddress(address domain.Address) *order.OrderAddress {
+	if address == (domain.Address{}) {
+		return nil
+	}
+	return &order.OrderAddress{
+		AddressId:     address.ID,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		Region:        address.Region,
+		PostalCode:    address.PostalCode,
+		Country:       address.Country,
 	}
 }
 
@@ -154,8 +190,25 @@ func (d *UpdateOrderRequestDTO) ToDomainFilterAndUpdate() (domain.OrderFilter, d
 
 func FromListOrdersRequestProto(req *order.ListOrdersRequest) *ListOrdersRequestDTO {
 	return &ListOrdersRequestDTO{
-		UserID: req.UserId,
-		Page:   req.Page,
-		Limit:  req.Limit,
+		UserID:    req.UserId,
+		Page:      req.Page,
+		Limit:     req.Limit,
+		PageToken: req.PageToken,
+		SkipTotal: req.SkipTotal,
+	}
+}
+
+// ToDomainPage converts DTO to the page to fetch
+func (d *ListOrdersRequestDTO) ToDomainPage() (domain.OrderPage, error) {
+	page := domain.OrderPage{Page: d.Page, Limit: d.Limit, SkipTotal: d.SkipTotal}
+	if d.PageToken == "" {
+		return page, nil
+	}
+
+	cursor, err := decodeOrderPageToken(d.PageToken)
+	if err != nil {
+		return domain.OrderPage{}, status.Error(codes.InvalidArgument, "page_token is malformed")
 	}
+	page.After = &cursor
+	return page, nil
 }
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/BeksultanSE/Assignment1-order/internal/domain"
+)
+
+// orderPageToken is the cursor handed out to clients, it is opaque to them and only read back here
+type orderPageToken struct {
+	ID uint64 `json:"i"`
+}
+
+// EncodeOrderPageToken turns the cursor into the next_page_token of a response, empty for the last page
+func EncodeOrderPageToken(cursor *domain.OrderCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	data, _ := json.Marshal(orderPageToken{ID: cursor.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeOrderPageToken(value string) (domain.OrderCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return domain.OrderCursor{}, err
+	}
+
+	var token orderPageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return domain.OrderCursor{}, err
+	}
+	return domain.OrderCursor{ID: token.ID}, nil
+}
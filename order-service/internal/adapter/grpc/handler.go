@@ -28,7 +28,20 @@ func (s *OrderGRPCServer) CreateOrder(ctx context.Context, req *proto.CreateOrde
 	domainOrder := requestDTO.ToDomainOrder()
 	createdOrder, err := s.orderUsecase.Create(ctx, domainOrder)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		switch {
+		case errors.Is(err, domain.ErrAddressNotFound):
+			return nil, status.Error(codes.NotFound, "address not found")
+		case errors.Is(err, domain.ErrNoDefaultAddress):
+			return nil, status.Error(codes.FailedPrecondition, "no default shipping address, select an address for the order")
+		case errors.Is(err, domain.ErrNoItems), errors.Is(err, domain.ErrInvalidQuantity):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, domain.ErrInsufficientStock):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, domain.ErrProductNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 	}
 
 	responseDTO := dto.FromDomainOrder(createdOrder)
@@ -72,20 +85,38 @@ func (s *OrderGRPCServer) UpdateOrder(ctx context.Context, req *proto.UpdateOrde
 func (s *OrderGRPCServer) ListOrders(ctx context.Context, req *proto.ListOrdersRequest) (*proto.ListOrdersResponse, error) {
 	requestDTO := dto.FromListOrdersRequestProto(req)
 	filter := domain.OrderFilter{UserID: &requestDTO.UserID}
+	page, err := requestDTO.ToDomainPage()
+	if err != nil {
+		return nil, err
+	}
 
-	orders, total, err := s.orderUsecase.GetAll(ctx, filter, requestDTO.Page, requestDTO.Limit)
+	list, err := s.orderUsecase.GetAll(ctx, filter, page)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	response := &proto.ListOrdersResponse{
-		Orders: make([]*proto.OrderResponse, len(orders)),
-		Total:  total,
+		Orders:        make([]*proto.OrderResponse, len(list.Orders)),
+		Total:         list.Total,
+		NextPageToken: dto.EncodeOrderPageToken(list.Next),
 	}
-	for i, ord := range orders {
+	for i, ord := range list.Orders {
 		responseDTO := dto.FromDomainOrder(ord)
 		response.Orders[i] = responseDTO.ToProtoOrderResponse()
 	}
 
 	return response, nil
 }
+
+func (s *OrderGRPCServer) PseudonymizeUserOrders(ctx context.Context, req *proto.PseudonymizeUserOrdersRequest) (*proto.PseudonymizeUserOrdersResponse, error) {
+	if req.UserId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	pseudonym, count, err := s.orderUsecase.PseudonymizeUser(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &proto.PseudonymizeUserOrdersResponse{Pseudonym: pseudonym, Orders: count}, nil
+}
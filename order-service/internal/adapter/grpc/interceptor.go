@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	proto "github.com/BeksultanSE/Assignment1-order/protos/gen/golang"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"slices"
+)
+
+// metadataUserRole carries the caller role attached by the api-gateway after it has verified the access token
+const metadataUserRole = "x-user-role"
+
+// Calling service and its token, attached by the gRPC clients of the other services
+const (
+	metadataServiceName  = "x-service-name"
+	metadataServiceToken = "x-service-token"
+)
+
+var orderStaff = []string{"admin", "sales_staff", "warehouse"}
+
+// methodRoles lists the RPCs restricted to specific caller roles, methods not listed here are open
+var methodRoles = map[string][]string{
+	proto.OrderService_UpdateOrder_FullMethodName: orderStaff,
+}
+
+// serviceMethods lists the RPCs other services call on their own behalf, with the services allowed to call them
+var serviceMethods = map[string][]string{
+	proto.OrderService_PseudonymizeUserOrders_FullMethodName: {"user-service"},
+}
+
+// callerServiceKey stores the name of the authenticated calling service in the context
+type callerServiceKey struct{}
+
+// authenticate rejects calls that do not come from one of the configured services, the identity and role
+// metadata of a call are only trusted once the calling service has presented its token
+func authenticate(callers map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		token, ok := callers[metadataValue(ctx, metadataServiceName)]
+		presented := metadataValue(ctx, metadataServiceToken)
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(presented)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "calling service is not authenticated")
+		}
+
+		return handler(context.WithValue(ctx, callerServiceKey{}, metadataValue(ctx, metadataServiceName)), req)
+	}
+}
+
+// authorize rejects calls to service methods from other callers and calls to restricted methods made without one of the allowed roles
+func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if services, ok := serviceMethods[info.FullMethod]; ok {
+		if !slices.Contains(services, callerService(ctx)) {
+			return nil, status.Error(codes.PermissionDenied, "caller is not allowed to perform this action")
+		}
+		return handler(ctx, req)
+	}
+
+	allowed, ok := methodRoles[info.FullMethod]
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	if !slices.Contains(allowed, callerRole(ctx)) {
+		return nil, status.Error(codes.PermissionDenied, "caller is not allowed to perform this action")
+	}
+
+	return handler(ctx, req)
+}
+
+func callerService(ctx context.Context) string {
+	name, _ := ctx.Value(callerServiceKey{}).(string)
+	return name
+}
+
+func callerRole(ctx context.Context) string {
+	return metadataValue(ctx, metadataUserRole)
+}
+
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
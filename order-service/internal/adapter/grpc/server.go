@@ -26,7 +26,7 @@ type ServerAPI struct {
 }
 
 func New(cfg config.Server, orderUsecase *usecase.Order) *ServerAPI {
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(authenticate(cfg.GRPCServer.Callers), authorize))
 
 	orderHandler := NewOrderGRPCServer(orderUsecase)
 	order.RegisterOrderServiceServer(grpcServer, orderHandler)
@@ -34,6 +34,7 @@ type OrderStatus string
 
 const (
 	StatusPending   OrderStatus = "pending"
+	StatusConfirmed OrderStatus = "confirmed"
 	StatusPaid      OrderStatus = "paid"
 	StatusShipped   OrderStatus = "shipped"
 	StatusDelivered OrderStatus = "delivered"
@@ -47,7 +48,7 @@ func (os *OrderStatus) UnmarshalJSON(b []byte) error {
 	}
 
 	switch OrderStatus(status) {
-	case StatusPending, StatusPaid, StatusShipped, StatusDelivered, StatusCancelled:
+	case StatusPending, StatusConfirmed, StatusPaid, StatusShipped, StatusDelivered, StatusCancelled:
 		*os = OrderStatus(status)
 		return nil
 	default:
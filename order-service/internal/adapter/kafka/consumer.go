@@ -0,0 +1,57 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-order/internal/usecase"
+	events "github.com/BeksultanSE/Assignment1-order/protos/gen/golang"
+	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/proto"
+	"log"
+)
+
+// UserDeletedConsumer cancels the pending orders of users whose account was deleted.
+// Erased users have their orders pseudonymized beforehand, so nothing is left to cancel for them.
+type UserDeletedConsumer struct {
+	usecase *usecase.Order
+	Topic   string
+	retry   RetryPolicy
+	dlq     *DeadLetterProducer
+}
+
+func NewUserDeletedConsumer(usecase *usecase.Order, topic string, retry RetryPolicy, dlq *DeadLetterProducer) *UserDeletedConsumer {
+	return &UserDeletedConsumer{usecase: usecase, Topic: topic, retry: retry, dlq: dlq}
+}
+
+func (h *UserDeletedConsumer) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *UserDeletedConsumer) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim retries a message whose orders could not be cancelled with backoff and dead-letters it once
+// the retries are used up
+func (h *UserDeletedConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	return consumeClaim(session, claim, h.dlq, h.process)
+}
+
+// process decodes the event and cancels the orders, messages that cannot be decoded are not retried as they would never succeed
+func (h *UserDeletedConsumer) process(ctx context.Context, message *sarama.ConsumerMessage) (int, error) {
+	var event events.UserDeletedEvent
+	if err := proto.Unmarshal(message.Value, &event); err != nil {
+		return 1, fmt.Errorf("failed to unmarshal UserDeletedEvent: %w", err)
+	}
+
+	return h.retry.run(ctx, fmt.Sprintf("deleted user %d", event.UserId), func(ctx context.Context) error {
+		cancelled, err := h.usecase.CancelUserOrders(ctx, event.UserId)
+		if err != nil {
+			return fmt.Errorf("failed to cancel pending orders of user %d: %w", event.UserId, err)
+		}
+		if cancelled > 0 {
+			log.Printf("Cancelled %d pending orders of deleted user %d", cancelled, event.UserId)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,78 @@
+package kafka
+
+import (
+	"github.com/IBM/sarama"
+	"strconv"
+	"time"
+)
+
+// Headers attached to dead-lettered messages, they describe where the message came from and why it failed.
+// They match the headers of inventory-service, so its dlq command can list and replay these messages too.
+const (
+	HeaderError             = "x-error"
+	HeaderAttempts          = "x-attempts"
+	HeaderFailedAt          = "x-failed-at"
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+)
+
+// deadLetterHeaders are replaced when a message that has been replayed fails again
+var deadLetterHeaders = map[string]bool{
+	HeaderError:             true,
+	HeaderAttempts:          true,
+	HeaderFailedAt:          true,
+	HeaderOriginalTopic:     true,
+	HeaderOriginalPartition: true,
+	HeaderOriginalOffset:    true,
+}
+
+// DeadLetterProducer moves messages that could not be processed to the dead-letter topic
+type DeadLetterProducer struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func NewDeadLetterProducer(brokers []string, topic string) (*DeadLetterProducer, error) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	return &DeadLetterProducer{producer: producer, topic: topic}, nil
+}
+
+// Send publishes the failed message unchanged, the error and the original position are added as headers
+func (p *DeadLetterProducer) Send(message *sarama.ConsumerMessage, cause error, attempts int) error {
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+6)
+	for _, header := range message.Headers {
+		if header != nil && !deadLetterHeaders[string(header.Key)] {
+			headers = append(headers, *header)
+		}
+	}
+	headers = append(headers,
+		header(HeaderError, cause.Error()),
+		header(HeaderAttempts, strconv.Itoa(attempts)),
+		header(HeaderFailedAt, time.Now().UTC().Format(time.RFC3339)),
+		header(HeaderOriginalTopic, message.Topic),
+		header(HeaderOriginalPartition, strconv.FormatInt(int64(message.Partition), 10)),
+		header(HeaderOriginalOffset, strconv.FormatInt(message.Offset, 10)),
+	)
+
+	_, _, err := p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   p.topic,
+		Key:     sarama.ByteEncoder(message.Key),
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	})
+	return err
+}
+
+func (p *DeadLetterProducer) Close() error {
+	return p.producer.Close()
+}
+
+func header(key, value string) sarama.RecordHeader {
+	return sarama.RecordHeader{Key: []byte(key), Value: []byte(value)}
+}
@@ -0,0 +1,103 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-order/internal/usecase"
+	events "github.com/BeksultanSE/Assignment1-order/protos/gen/golang"
+	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/proto"
+	"log"
+)
+
+const (
+	TopicInventoryReserved = "inventory.reserved"
+	TopicInventoryRejected = "inventory.rejected"
+)
+
+// InventoryConsumer completes the order saga: orders whose stock has been deducted are confirmed, orders
+// inventory rejected are cancelled. Only pending orders are changed, so redelivered events are harmless.
+// Orders cancelled before their stock was deducted get the deducted stock returned.
+type InventoryConsumer struct {
+	usecase *usecase.Order
+	Topics  []string
+	retry   RetryPolicy
+	dlq     *DeadLetterProducer
+}
+
+func NewInventoryConsumer(usecase *usecase.Order, retry RetryPolicy, dlq *DeadLetterProducer) *InventoryConsumer {
+	return &InventoryConsumer{
+		usecase: usecase,
+		Topics:  []string{TopicInventoryReserved, TopicInventoryRejected},
+		retry:   retry,
+		dlq:     dlq,
+	}
+}
+
+func (h *InventoryConsumer) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *InventoryConsumer) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim retries a message whose order could not be updated with backoff and dead-letters it once the
+// retries are used up
+func (h *InventoryConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	return consumeClaim(session, claim, h.dlq, h.process)
+}
+
+// process decodes the event and handles it, messages that cannot be decoded are not retried as they would never succeed
+func (h *InventoryConsumer) process(ctx context.Context, message *sarama.ConsumerMessage) (int, error) {
+	switch message.Topic {
+	case TopicInventoryReserved:
+		var event events.InventoryReservedEvent
+		if err := proto.Unmarshal(message.Value, &event); err != nil {
+			return 1, fmt.Errorf("failed to unmarshal InventoryReservedEvent: %w", err)
+		}
+		return h.retry.run(ctx, fmt.Sprintf("reserved order %d", event.OrderId), func(ctx context.Context) error {
+			return h.handleReserved(ctx, &event)
+		})
+
+	case TopicInventoryRejected:
+		var event events.InventoryRejectedEvent
+		if err := proto.Unmarshal(message.Value, &event); err != nil {
+			return 1, fmt.Errorf("failed to unmarshal InventoryRejectedEvent: %w", err)
+		}
+		return h.retry.run(ctx, fmt.Sprintf("rejected order %d", event.OrderId), func(ctx context.Context) error {
+			return h.handleRejected(ctx, &event)
+		})
+	}
+	return 1, nil
+}
+
+func (h *InventoryConsumer) handleReserved(ctx context.Context, event *events.InventoryReservedEvent) error {
+	confirmed, err := h.usecase.ConfirmOrder(ctx, event.OrderId)
+	if err != nil {
+		return fmt.Errorf("failed to confirm order %d: %w", event.OrderId, err)
+	}
+	if confirmed {
+		return nil
+	}
+
+	restocked, err := h.usecase.RestockCancelledOrder(ctx, event.OrderId)
+	if err != nil {
+		return fmt.Errorf("failed to return deducted stock of order %d: %w", event.OrderId, err)
+	}
+	if restocked {
+		log.Printf("Order %d was cancelled before its stock was deducted, the stock has been returned", event.OrderId)
+	}
+	return nil
+}
+
+func (h *InventoryConsumer) handleRejected(ctx context.Context, event *events.InventoryRejectedEvent) error {
+	cancelled, err := h.usecase.RejectOrder(ctx, event.OrderId, event.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to cancel order %d: %w", event.OrderId, err)
+	}
+	if cancelled {
+		log.Printf("Cancelled order %d rejected by inventory: %s", event.OrderId, event.Message)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"github.com/IBM/sarama"
+	"log"
+	"time"
+)
+
+// RetryPolicy tells how often a failed message is retried before it is dead-lettered. The backoff doubles
+// after every attempt up to MaxBackoff.
+type RetryPolicy struct {
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// run calls fn until it succeeds or the retries are used up and returns the number of attempts made.
+// subject describes what fn processes in the log.
+func (p RetryPolicy) run(ctx context.Context, subject string, fn func(ctx context.Context) error) (int, error) {
+	backoff := p.Backoff
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt > p.MaxRetries {
+			return attempt, err
+		}
+
+		log.Printf("Failed to process %s (attempt %d), retrying in %s: %v", subject, attempt, backoff, err)
+		select {
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, p.MaxBackoff)
+	}
+}
+
+// consumeClaim marks a message only once process has handled it or it has been dead-lettered, so a failing
+// message neither gets lost nor stops the partition. process returns the number of attempts it made.
+// Messages left unmarked on shutdown or rebalance are delivered again.
+func consumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim, dlq *DeadLetterProducer,
+	process func(ctx context.Context, message *sarama.ConsumerMessage) (int, error)) error {
+	for message := range claim.Messages() {
+		attempts, err := process(session.Context(), message)
+		if err != nil {
+			if session.Context().Err() != nil {
+				return nil
+			}
+
+			if errDLQ := dlq.Send(message, err, attempts); errDLQ != nil {
+				return fmt.Errorf("failed to dead-letter message at %s/%d offset %d: %w", message.Topic, message.Partition, message.Offset, errDLQ)
+			}
+			log.Printf("Moved message at %s/%d offset %d to dead-letter topic after %d attempts: %v", message.Topic, message.Partition, message.Offset, attempts, err)
+		}
+		session.MarkMessage(message, "")
+	}
+	return nil
+}
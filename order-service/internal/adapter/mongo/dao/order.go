@@ -7,13 +7,28 @@ import (
 )
 
 type Order struct {
-	ID          uint64      `bson:"_id"`
-	UserID      uint64      `bson:"userId"`
-	Items       []OrderItem `bson:"items"`
-	TotalAmount float64     `bson:"totalAmount"`
-	Status      string      `bson:"status"`
-	CreatedAt   time.Time   `bson:"createdAt"`
-	UpdatedAt   time.Time   `bson:"updatedAt"`
+	ID              uint64      `bson:"_id"`
+	UserID          uint64      `bson:"userId"`
+	Items           []OrderItem `bson:"items"`
+	TotalAmount     float64     `bson:"totalAmount"`
+	Status          string      `bson:"status"`
+	StatusReason    string      `bson:"statusReason,omitempty"`
+	ShippingAddress *Address    `bson:"shippingAddress,omitempty"`
+	BillingAddress  *Address    `bson:"billingAddress,omitempty"`
+	CreatedAt       time.Time   `bson:"createdAt"`
+	UpdatedAt       time.Time   `bson:"updatedAt"`
+}
+
+type Address struct {
+	ID            uint64 `bson:"addressId"`
+	RecipientName string `bson:"recipientName"`
+	Phone         string `bson:"phone"`
+	Line1         string `bson:"line1"`
+	Line2         string `bson:"line2"`
+	City          string `bson:"city"`
+	Region        string `bson:"region"`
+	PostalCode    string `bson:"postalCode"`
+	Country       string `bson:"country"`
 }
 
 type OrderItem struct {
@@ -25,40 +40,73 @@ type OrderItem struct {
 func ToOrderList(daoOrders []Order) []domain.Order {
 	orders := make([]domain.Order, len(daoOrders))
 	for i, o := range daoOrders {
-		orders[i] = domain.Order{
-			ID:          o.ID,
-			UserID:      o.UserID,
-			Items:       ToOrderItemList(o.Items),
-			TotalAmount: o.TotalAmount,
-			Status:      domain.OrderStatus(o.Status),
-			CreatedAt:   o.CreatedAt,
-			UpdatedAt:   o.UpdatedAt,
-		}
+		orders[i] = ToOrder(o)
 	}
 	return orders
 }
 
 func ToOrder(daoOrder Order) domain.Order {
 	return domain.Order{
-		ID:          daoOrder.ID,
-		UserID:      daoOrder.UserID,
-		Items:       ToOrderItemList(daoOrder.Items),
-		TotalAmount: daoOrder.TotalAmount,
-		Status:      domain.OrderStatus(daoOrder.Status),
-		CreatedAt:   daoOrder.CreatedAt,
-		UpdatedAt:   daoOrder.UpdatedAt,
+		ID:              daoOrder.ID,
+		UserID:          daoOrder.UserID,
+		Items:           ToOrderItemList(daoOrder.Items),
+		TotalAmount:     daoOrder.TotalAmount,
+		Status:          domain.OrderStatus(daoOrder.Status),
+		StatusReason:    daoOrder.StatusReason,
+		ShippingAddress: ToAddress(daoOrder.ShippingAddress),
+		BillingAddress:  ToAddress(daoOrder.BillingAddress),
+		CreatedAt:       daoOrder.CreatedAt,
+		UpdatedAt:       daoOrder.UpdatedAt,
 	}
 }
 
 func FromOrder(order domain.Order) Order {
 	return Order{
-		ID:          order.ID,
-		UserID:      order.UserID,
-		Items:       FromOrderItemList(order.Items),
-		TotalAmount: order.TotalAmount,
-		Status:      string(order.Status),
-		CreatedAt:   order.CreatedAt,
-		UpdatedAt:   order.UpdatedAt,
+		ID:              order.ID,
+		UserID:          order.UserID,
+		Items:           FromOrderItemList(order.Items),
+		TotalAmount:     order.TotalAmount,
+		Status:          string(order.Status),
+		StatusReason:    order.StatusReason,
+		ShippingAddress: FromAddress(order.ShippingAddress),
+		BillingAddress:  FromAddress(order.BillingAddress),
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
+	}
+}
+
+// ToAddress converts an address snapshot, orders placed before addresses were introduced have none
+func ToAddress(address *Address) domain.Address {
+	if address == nil {
+		return domain.Address{}
+	}
+	return domain.Address{
+		ID:            address.ID,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		Region:        address.Region,
+		PostalCode:    address.PostalCode,
+		Country:       address.Country,
+	}
+}
+
+func FromAddress(address domain.Address) *Address {
+	if address == (domain.Address{}) {
+		return nil
+	}
+	return &Address{
+		ID:            address.ID,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		Region:        address.Region,
+		PostalCode:    address.PostalCode,
+		Country:       address.Country,
 	}
 }
 
@@ -119,6 +167,10 @@ func FromOrderUpdateData(updateData domain.OrderUpdateData) bson.M {
 		query["status"] = string(*updateData.Status)
 	}
 
+	if updateData.StatusReason != nil {
+		query["statusReason"] = *updateData.StatusReason
+	}
+
 	if updateData.UpdatedAt != nil {
 		query["updatedAt"] = updateData.UpdatedAt
 	}
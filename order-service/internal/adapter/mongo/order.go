@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"github.com/BeksultanSE/Assignment1-order/internal/adapter/mongo/dao"
 	"github.com/BeksultanSE/Assignment1-order/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"log"
+	"time"
 )
 
 type OrderRepo struct {
@@ -23,6 +25,17 @@ func NewOrderRepo(conn *mongo.Database) *OrderRepo {
 	}
 }
 
+// EnsureIndexes lets the orders of a user be listed page by page without scanning the others
+func (o *OrderRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := o.conn.Collection(o.collection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "userId", Value: 1}, {Key: "_id", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create order indexes: %w", err)
+	}
+	return nil
+}
+
 func (o *OrderRepo) Create(ctx context.Context, order domain.Order) error {
 	orderDoc := dao.FromOrder(order)
 	_, err := o.conn.Collection(o.collection).InsertOne(ctx, orderDoc)
@@ -65,28 +78,38 @@ func (o *OrderRepo) GetWithFilter(ctx context.Context, filter domain.OrderFilter
 	return dao.ToOrder(orderDAO), nil
 }
 
-func (o *OrderRepo) GetAllWithFilter(ctx context.Context, filter domain.OrderFilter, page, limit int64) ([]domain.Order, int64, error) {
+// GetAllWithFilter retrieves a page of orders ordered by id. Pages after a cursor start right after its id,
+// so deep pages stay fast and orders placed in between do not shift them.
+func (o *OrderRepo) GetAllWithFilter(ctx context.Context, filter domain.OrderFilter, page domain.OrderPage) (domain.OrderList, error) {
 	// Create the filter for the query
 	findFilter := dao.FromOrderFilter(filter)
 
-	// Calculate pagination parameters
-	skip := (page - 1) * limit
-
-	// Set up find options for pagination
+	// one more order than asked for tells whether there is a next page
 	findOptions := options.Find()
-	findOptions.SetSkip(int64(skip))
-	findOptions.SetLimit(int64(limit))
+	findOptions.SetSort(bson.D{{Key: "_id", Value: 1}})
+	if page.Limit > 0 {
+		findOptions.SetLimit(page.Limit + 1)
+	}
 
-	// Get total count
-	totalCount, err := o.conn.Collection(o.collection).CountDocuments(ctx, findFilter)
-	if err != nil {
-		return nil, 0, err
+	var totalCount int64
+	if !page.SkipTotal {
+		var err error
+		totalCount, err = o.conn.Collection(o.collection).CountDocuments(ctx, findFilter)
+		if err != nil {
+			return domain.OrderList{}, err
+		}
+	}
+
+	if page.After != nil {
+		findFilter["_id"] = bson.M{"$gt": page.After.ID}
+	} else if page.Page > 1 {
+		findOptions.SetSkip((page.Page - 1) * page.Limit)
 	}
 
 	// Execute the query with pagination
 	cursor, err := o.conn.Collection(o.collection).Find(ctx, findFilter, findOptions)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to find orders: %w", err)
+		return domain.OrderList{}, fmt.Errorf("failed to find orders: %w", err)
 	}
 	defer func(cursor *mongo.Cursor, ctx context.Context) {
 		if err := cursor.Close(ctx); err != nil {
@@ -96,10 +119,15 @@ func (o *OrderRepo) GetAllWithFilter(ctx context.Context, filter domain.OrderFil
 
 	var daoOrders []dao.Order
 	if err := cursor.All(ctx, &daoOrders); err != nil {
-		return nil, 0, fmt.Errorf("failed to decode orders: %w", err)
+		return domain.OrderList{}, fmt.Errorf("failed to decode orders: %w", err)
 	}
-	orders := dao.ToOrderList(daoOrders)
-	return orders, totalCount, nil
+
+	list := domain.OrderList{Orders: dao.ToOrderList(daoOrders), Total: totalCount}
+	if page.Limit > 0 && int64(len(list.Orders)) > page.Limit {
+		list.Orders = list.Orders[:page.Limit]
+		list.Next = &domain.OrderCursor{ID: list.Orders[page.Limit-1].ID}
+	}
+	return list, nil
 }
 
 func (o *OrderRepo) Delete(ctx context.Context, filter domain.OrderFilter) error {
@@ -117,3 +145,40 @@ func (o *OrderRepo) Delete(ctx context.Context, filter domain.OrderFilter) error
 
 	return nil
 }
+
+// PseudonymizeUser replaces the user id on all orders of the user and removes the personal parts of the
+// address snapshots. Items and amounts are left untouched so totals used for accounting do not change.
+func (o *OrderRepo) PseudonymizeUser(ctx context.Context, userID, pseudonym uint64, now time.Time) (int64, error) {
+	personal := bson.M{}
+	for _, address := range []string{"shippingAddress", "billingAddress"} {
+		for _, field := range []string{"recipientName", "phone", "line1", "line2", "city", "postalCode"} {
+			personal[address+"."+field] = ""
+		}
+	}
+
+	res, err := o.conn.Collection(o.collection).UpdateMany(
+		ctx,
+		bson.M{"userId": userID},
+		bson.M{
+			"$set":   bson.M{"userId": pseudonym, "pseudonymizedAt": now},
+			"$unset": personal,
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to pseudonymize orders of user %d: %w", userID, err)
+	}
+	return res.ModifiedCount, nil
+}
+
+// CancelPendingByUser cancels all orders of the user that have not been paid yet
+func (o *OrderRepo) CancelPendingByUser(ctx context.Context, userID uint64, now time.Time) (int64, error) {
+	res, err := o.conn.Collection(o.collection).UpdateMany(
+		ctx,
+		bson.M{"userId": userID, "status": string(domain.StatusPending)},
+		bson.M{"$set": bson.M{"status": string(domain.StatusCancelled), "updatedAt": now}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel pending orders of user %d: %w", userID, err)
+	}
+	return res.ModifiedCount, nil
+}
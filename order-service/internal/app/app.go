@@ -10,19 +10,34 @@ import (
 	mongoRepo "github.com/BeksultanSE/Assignment1-order/internal/adapter/mongo"
 	"github.com/BeksultanSE/Assignment1-order/internal/usecase"
 	mongoConn "github.com/BeksultanSE/Assignment1-order/pkg/mongo"
+	"github.com/IBM/sarama"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
-const serviceName = "order-service"
+const (
+	serviceName                = "order-service"
+	consumerGroupName          = "order-consumer-group"
+	inventoryConsumerGroupName = "order-inventory-consumer-group"
+
+	// consumerRestartDelay is the pause before a consumer group joins again after consuming failed
+	consumerRestartDelay = 5 * time.Second
+)
 
 type App struct {
 	//httpServer *httpRepo.API
-	grpcServer  *grpcAPI.ServerAPI
-	grpcClients *gclients.Clients
-	kafkaProd   *kafka.Producer
+	grpcServer    *grpcAPI.ServerAPI
+	grpcClients   *gclients.Clients
+	kafkaProd     *kafka.Producer
+	consumerGroup sarama.ConsumerGroup
+	kafkaHandler  *kafka.UserDeletedConsumer
+	dlqProducer   *kafka.DeadLetterProducer
+
+	inventoryConsumerGroup sarama.ConsumerGroup
+	inventoryHandler       *kafka.InventoryConsumer
 }
 
 func New(ctx context.Context, cfg *config.Config) (*App, error) {
@@ -36,26 +51,62 @@ func New(ctx context.Context, cfg *config.Config) (*App, error) {
 
 	aiRepo := mongoRepo.NewAutoInc(mongoDB.Conn)
 	orderRepo := mongoRepo.NewOrderRepo(mongoDB.Conn)
+	if err = orderRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("error preparing order collection: %v", err)
+	}
 
 	grpcClients, err := gclients.NewClients(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize gRPC clients: %w", err)
 	}
 	inventoryClient := gclients.NewInventoryClient(grpcClients.Inventory)
+	userClient := gclients.NewUserClient(grpcClients.User)
 
 	producer, err := kafka.NewKafkaProducer(cfg.Brokers, "order.created")
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize kafka producer: %w", err)
 	}
 
-	orderUsecase := usecase.NewOrder(aiRepo, orderRepo, inventoryClient, producer)
+	orderUsecase := usecase.NewOrder(aiRepo, orderRepo, inventoryClient, userClient, producer)
 
 	//httpServer := httpRepo.New(cfg.Server, orderUsecase)
 	grpcServer := grpcAPI.New(cfg.Server, orderUsecase)
 
+	dlqProducer, err := kafka.NewDeadLetterProducer(cfg.Brokers, cfg.Consumer.DeadLetterTopic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize dead-letter producer: %w", err)
+	}
+	retryPolicy := kafka.RetryPolicy{
+		MaxRetries: cfg.Consumer.MaxRetries,
+		Backoff:    cfg.Consumer.RetryBackoff,
+		MaxBackoff: cfg.Consumer.MaxRetryBackoff,
+	}
+
+	kafkaConfig := sarama.NewConfig()
+	kafkaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+	consumerGroup, err := sarama.NewConsumerGroup(cfg.Brokers, consumerGroupName, kafkaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consumer group: %w", err)
+	}
+	kafkaHandler := kafka.NewUserDeletedConsumer(orderUsecase, "user.deleted", retryPolicy, dlqProducer)
+
+	inventoryConsumerGroup, err := sarama.NewConsumerGroup(cfg.Brokers, inventoryConsumerGroupName, kafkaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inventory consumer group: %w", err)
+	}
+	inventoryHandler := kafka.NewInventoryConsumer(orderUsecase, retryPolicy, dlqProducer)
+
 	app := &App{
 		//httpServer: httpServer,
-		grpcServer: grpcServer,
+		grpcServer:    grpcServer,
+		grpcClients:   grpcClients,
+		kafkaProd:     producer,
+		consumerGroup: consumerGroup,
+		kafkaHandler:  kafkaHandler,
+		dlqProducer:   dlqProducer,
+
+		inventoryConsumerGroup: inventoryConsumerGroup,
+		inventoryHandler:       inventoryHandler,
 	}
 
 	return app, nil
@@ -67,6 +118,26 @@ func (app *App) Start() error {
 	//app.httpServer.Run(errCh)
 	app.grpcServer.Run(errCh)
 
+	// Start Kafka consumer in background
+	go func() {
+		for {
+			if err := app.consumerGroup.Consume(context.Background(), []string{app.kafkaHandler.Topic}, app.kafkaHandler); err != nil {
+				log.Printf("Consumer error: %v", err)
+				time.Sleep(consumerRestartDelay)
+			}
+		}
+	}()
+
+	// Confirm or cancel orders as inventory reports back on their stock
+	go func() {
+		for {
+			if err := app.inventoryConsumerGroup.Consume(context.Background(), app.inventoryHandler.Topics, app.inventoryHandler); err != nil {
+				log.Printf("Inventory consumer error: %v", err)
+				time.Sleep(consumerRestartDelay)
+			}
+		}
+	}()
+
 	log.Printf(fmt.Sprintf("Starting %s service...", serviceName))
 
 	shutdownCh := make(chan os.Signal, 1)
@@ -93,4 +164,13 @@ func (app *App) Stop() {
 	if err := app.kafkaProd.Close(); err != nil {
 		log.Println("failed to close Kafka producer:", err)
 	}
+	if err := app.consumerGroup.Close(); err != nil {
+		log.Println("failed to close consumer group:", err)
+	}
+	if err := app.inventoryConsumerGroup.Close(); err != nil {
+		log.Println("failed to close inventory consumer group:", err)
+	}
+	if err := app.dlqProducer.Close(); err != nil {
+		log.Println("failed to close dead-letter producer:", err)
+	}
 }
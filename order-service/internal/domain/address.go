@@ -0,0 +1,23 @@
+package domain
+
+// Address is a snapshot of a user's address book entry, copied onto the order when it is placed
+// so later changes to the address book do not alter past orders
+type Address struct {
+	ID            uint64 // address book entry the snapshot was taken from
+	RecipientName string
+	Phone         string
+	Line1         string
+	Line2         string
+	City          string
+	Region        string
+	PostalCode    string
+	Country       string
+}
+
+// AddressType selects which default address of the user is used
+type AddressType string
+
+const (
+	AddressShipping AddressType = "shipping"
+	AddressBilling  AddressType = "billing"
+)
@@ -4,3 +4,8 @@ import "errors"
 
 var ErrOrderNotFound = errors.New("order not found")
 var ErrProductNotFound = errors.New("product not found")
+var ErrAddressNotFound = errors.New("address not found")
+var ErrNoDefaultAddress = errors.New("no default address, an address must be selected")
+var ErrNoItems = errors.New("an order needs at least one item")
+var ErrInsufficientStock = errors.New("insufficient stock")
+var ErrInvalidQuantity = errors.New("invalid quantity, must be positive amount")
@@ -4,13 +4,16 @@ import "time"
 
 // Order represents the core order entity
 type Order struct {
-	ID          uint64
-	UserID      uint64
-	Items       []OrderItem
-	TotalAmount float64
-	Status      OrderStatus
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID              uint64
+	UserID          uint64
+	Items           []OrderItem
+	TotalAmount     float64
+	Status          OrderStatus
+	StatusReason    string // why the order has been rejected by inventory
+	ShippingAddress Address
+	BillingAddress  Address
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 // OrderStatus represents the current state of an order
@@ -18,6 +21,7 @@ type OrderStatus string
 
 const (
 	StatusPending   OrderStatus = "pending"
+	StatusConfirmed OrderStatus = "confirmed" // inventory has deducted the stock of the order
 	StatusPaid      OrderStatus = "paid"
 	StatusShipped   OrderStatus = "shipped"
 	StatusDelivered OrderStatus = "delivered"
@@ -44,6 +48,27 @@ type OrderFilter struct {
 
 // OrderUpdateData represents the data needed to update an order
 type OrderUpdateData struct {
-	Status    *OrderStatus
-	UpdatedAt *time.Time
+	Status       *OrderStatus
+	StatusReason *string
+	UpdatedAt    *time.Time
+}
+
+// OrderCursor marks the last order of a page, orders are listed by id so the next page starts right after it
+type OrderCursor struct {
+	ID uint64
+}
+
+// OrderPage selects a page of a listing, by offset or, when After is set, right after a cursor
+type OrderPage struct {
+	Page      int64
+	Limit     int64
+	After     *OrderCursor
+	SkipTotal bool
+}
+
+// OrderList is a page of an order listing
+type OrderList struct {
+	Orders []Order
+	Total  int64        // 0 when the page skipped counting
+	Next   *OrderCursor // nil on the last page
 }
@@ -8,6 +8,7 @@ type Product struct {
 	Category  string
 	Price     float64
 	Stock     uint64
+	Available uint64 // stock not held by other orders
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"github.com/BeksultanSE/Assignment1-order/internal/domain"
+	"time"
 )
 
 type AutoIncRepo interface {
@@ -14,12 +15,23 @@ type OrderRepository interface {
 	Create(ctx context.Context, order domain.Order) error
 	Update(ctx context.Context, filter domain.OrderFilter, update domain.OrderUpdateData) error
 	GetWithFilter(ctx context.Context, filter domain.OrderFilter) (domain.Order, error)
-	GetAllWithFilter(ctx context.Context, filter domain.OrderFilter, page, limit int64) ([]domain.Order, int64, error)
+	GetAllWithFilter(ctx context.Context, filter domain.OrderFilter, page domain.OrderPage) (domain.OrderList, error)
 	Delete(ctx context.Context, filter domain.OrderFilter) error
+	PseudonymizeUser(ctx context.Context, userID, pseudonym uint64, now time.Time) (int64, error)
+	CancelPendingByUser(ctx context.Context, userID uint64, now time.Time) (int64, error)
 }
 
 type InventoryClient interface {
 	GetProduct(ctx context.Context, productID uint64) (domain.Product, error)
+	GetProducts(ctx context.Context, productIDs []uint64) (map[uint64]domain.Product, error)
+	ReserveStock(ctx context.Context, orderID uint64, items []domain.OrderItem) error
+	ReleaseStock(ctx context.Context, orderID uint64) error
+	RestockOrder(ctx context.Context, orderID uint64) error
+}
+
+type UserClient interface {
+	GetAddress(ctx context.Context, userID, addressID uint64) (domain.Address, error)
+	GetDefaultAddress(ctx context.Context, userID uint64, addressType domain.AddressType) (domain.Address, error)
 }
 
 type EventPublisher interface {
@@ -2,9 +2,13 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"github.com/BeksultanSE/Assignment1-order/internal/adapter/mongo"
 	"github.com/BeksultanSE/Assignment1-order/internal/domain"
+	"log"
 	"time"
 )
 
@@ -12,19 +16,32 @@ type Order struct {
 	aiRepo          AutoIncRepo
 	repo            OrderRepository
 	inventoryClient InventoryClient
+	userClient      UserClient
 	eventPublisher  EventPublisher
 }
 
-func NewOrder(aiRepo AutoIncRepo, repo OrderRepository, client InventoryClient, publisher EventPublisher) *Order {
+func NewOrder(aiRepo AutoIncRepo, repo OrderRepository, client InventoryClient, userClient UserClient, publisher EventPublisher) *Order {
 	return &Order{
 		aiRepo:          aiRepo,
 		repo:            repo,
 		inventoryClient: client,
+		userClient:      userClient,
 		eventPublisher:  publisher,
 	}
 }
 
 func (o *Order) Create(ctx context.Context, order domain.Order) (domain.Order, error) {
+	if len(order.Items) == 0 {
+		return domain.Order{}, domain.ErrNoItems
+	}
+
+	shipping, billing, err := o.resolveAddresses(ctx, order.UserID, order.ShippingAddress.ID, order.BillingAddress.ID)
+	if err != nil {
+		return domain.Order{}, err
+	}
+	order.ShippingAddress = shipping
+	order.BillingAddress = billing
+
 	totalAmount := 0.0
 	for i, item := range order.Items {
 		product, err := o.inventoryClient.GetProduct(ctx, item.ProductID)
@@ -32,12 +49,13 @@ func (o *Order) Create(ctx context.Context, order domain.Order) (domain.Order, e
 			return domain.Order{}, err
 		}
 
-		if product.Stock < item.Quantity {
-			return domain.Order{}, errors.New("insufficient stock for product: " + product.Name)
+		if item.Quantity <= 0 {
+			return domain.Order{}, fmt.Errorf("%w for product: %s", domain.ErrInvalidQuantity, product.Name)
 		}
 
-		if item.Quantity <= 0 {
-			return domain.Order{}, errors.New("invalid quantity for product, must be positive amount: " + product.Name)
+		// units held by other orders cannot be ordered
+		if product.Available < item.Quantity {
+			return domain.Order{}, fmt.Errorf("%w for product: %s", domain.ErrInsufficientStock, product.Name)
 		}
 
 		order.Items[i].Name = product.Name
@@ -58,8 +76,16 @@ func (o *Order) Create(ctx context.Context, order domain.Order) (domain.Order, e
 	}
 	order.ID = id
 
+	// the units stay held until inventory processes order.created, so no other checkout can take them meanwhile
+	if err = o.inventoryClient.ReserveStock(ctx, order.ID, order.Items); err != nil {
+		return domain.Order{}, err
+	}
+
 	err = o.repo.Create(ctx, order)
 	if err != nil {
+		if errRelease := o.inventoryClient.ReleaseStock(context.WithoutCancel(ctx), order.ID); errRelease != nil {
+			log.Printf("failed to release stock held for order %d: %v", order.ID, errRelease)
+		}
 		return domain.Order{}, err
 	}
 
@@ -68,63 +94,112 @@ func (o *Order) Create(ctx context.Context, order domain.Order) (domain.Order, e
 	}
 
 	return domain.Order{
-		ID:     order.ID,
-		UserID: order.UserID,
-		Status: order.Status,
+		ID:              order.ID,
+		UserID:          order.UserID,
+		Status:          order.Status,
+		ShippingAddress: order.ShippingAddress,
+		BillingAddress:  order.BillingAddress,
 		// Include other fields as necessary
 	}, nil
 }
 
+// resolveAddresses snapshots the selected addresses from the user's address book. Without a selection the
+// default shipping address is used, billing falls back to the default billing address and then to shipping.
+func (o *Order) resolveAddresses(ctx context.Context, userID, shippingID, billingID uint64) (domain.Address, domain.Address, error) {
+	var shipping domain.Address
+	var err error
+	if shippingID != 0 {
+		shipping, err = o.userClient.GetAddress(ctx, userID, shippingID)
+	} else {
+		shipping, err = o.userClient.GetDefaultAddress(ctx, userID, domain.AddressShipping)
+	}
+	if err != nil {
+		return domain.Address{}, domain.Address{}, err
+	}
+
+	if billingID == shipping.ID {
+		return shipping, shipping, nil
+	}
+	if billingID != 0 {
+		billing, err := o.userClient.GetAddress(ctx, userID, billingID)
+		if err != nil {
+			return domain.Address{}, domain.Address{}, err
+		}
+		return shipping, billing, nil
+	}
+
+	billing, err := o.userClient.GetDefaultAddress(ctx, userID, domain.AddressBilling)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoDefaultAddress) {
+			return shipping, shipping, nil
+		}
+		return domain.Address{}, domain.Address{}, err
+	}
+	return shipping, billing, nil
+}
+
 func (o *Order) Get(ctx context.Context, filter domain.OrderFilter) (domain.Order, error) {
 	order, err := o.repo.GetWithFilter(ctx, filter)
 	if err != nil {
 		return domain.Order{}, err
 	}
 
-	// Fetch product details for each item
-	for i, item := range order.Items {
-		product, err := o.inventoryClient.GetProduct(ctx, item.ProductID)
-		if err != nil {
-			return domain.Order{}, err
-		}
-		order.Items[i].Name = product.Name
-		order.Items[i].Price = product.Price
-		order.Items[i].TotalPrice = float64(item.Quantity) * product.Price
+	orders := []domain.Order{order}
+	if err = o.fillProductDetails(ctx, orders); err != nil {
+		return domain.Order{}, err
 	}
 
-	order.TotalAmount = 0
-	for _, item := range order.Items {
-		order.TotalAmount += item.TotalPrice
+	return orders[0], nil
+}
+
+func (o *Order) GetAll(ctx context.Context, filter domain.OrderFilter, page domain.OrderPage) (domain.OrderList, error) {
+	list, err := o.repo.GetAllWithFilter(ctx, filter, page)
+	if err != nil {
+		return domain.OrderList{}, err
+	}
+	if err = o.fillProductDetails(ctx, list.Orders); err != nil {
+		return domain.OrderList{}, err
 	}
 
-	return order, nil
+	return list, nil
 }
 
-func (o *Order) GetAll(ctx context.Context, filter domain.OrderFilter, page, limit int64) ([]domain.Order, int64, error) {
-	orders, total, err := o.repo.GetAllWithFilter(ctx, filter, page, limit)
+// fillProductDetails sets the current name and price of the products on the items of the orders and
+// recalculates the totals. All products are fetched with one call, however many orders there are.
+func (o *Order) fillProductDetails(ctx context.Context, orders []domain.Order) error {
+	var productIDs []uint64
+	seen := make(map[uint64]bool)
+	for _, order := range orders {
+		for _, item := range order.Items {
+			if !seen[item.ProductID] {
+				seen[item.ProductID] = true
+				productIDs = append(productIDs, item.ProductID)
+			}
+		}
+	}
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	products, err := o.inventoryClient.GetProducts(ctx, productIDs)
 	if err != nil {
-		return nil, 0, err
+		return err
 	}
 
-	// Fetch product details for each item in each order
 	for i, order := range orders {
+		orders[i].TotalAmount = 0
 		for j, item := range order.Items {
-			product, err := o.inventoryClient.GetProduct(ctx, item.ProductID)
-			if err != nil {
-				return nil, 0, err
+			product, ok := products[item.ProductID]
+			if !ok {
+				return fmt.Errorf("%w: %d", domain.ErrProductNotFound, item.ProductID)
 			}
 			orders[i].Items[j].Name = product.Name
 			orders[i].Items[j].Price = product.Price
 			orders[i].Items[j].TotalPrice = float64(item.Quantity) * product.Price
-		}
-
-		orders[i].TotalAmount = 0
-		for _, item := range order.Items {
-			orders[i].TotalAmount += item.TotalPrice
+			orders[i].TotalAmount += orders[i].Items[j].TotalPrice
 		}
 	}
-
-	return orders, total, nil
+	return nil
 }
 
 func (o *Order) Update(ctx context.Context, filter domain.OrderFilter, updated domain.OrderUpdateData) error {
@@ -145,3 +220,75 @@ func (o *Order) Delete(ctx context.Context, filter domain.OrderFilter) error {
 	}
 	return nil
 }
+
+// PseudonymizeUser detaches all orders from an erased user under a random pseudonym and returns it
+// with the number of affected orders. Pseudonyms are taken from [2^62, 2^63), far above any user id,
+// and stay below 2^63 as mongo stores them as int64.
+func (o *Order) PseudonymizeUser(ctx context.Context, userID uint64) (uint64, int64, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return 0, 0, fmt.Errorf("failed to generate pseudonym: %w", err)
+	}
+	pseudonym := binary.BigEndian.Uint64(b)&(1<<62-1) | 1<<62
+
+	count, err := o.repo.PseudonymizeUser(ctx, userID, pseudonym, time.Now())
+	if err != nil {
+		return 0, 0, err
+	}
+	return pseudonym, count, nil
+}
+
+// CancelUserOrders cancels the pending orders of a user whose account was deleted
+func (o *Order) CancelUserOrders(ctx context.Context, userID uint64) (int64, error) {
+	return o.repo.CancelPendingByUser(ctx, userID, time.Now())
+}
+
+// ConfirmOrder moves a pending order to confirmed once inventory has deducted its stock. It reports false for
+// orders that are no longer pending, e.g. on redelivered events or for orders cancelled in the meantime.
+func (o *Order) ConfirmOrder(ctx context.Context, orderID uint64) (bool, error) {
+	return o.resolvePending(ctx, orderID, domain.StatusConfirmed, "")
+}
+
+// RejectOrder cancels a pending order whose stock inventory could not deduct, the reason is kept on the order
+func (o *Order) RejectOrder(ctx context.Context, orderID uint64, reason string) (bool, error) {
+	return o.resolvePending(ctx, orderID, domain.StatusCancelled, reason)
+}
+
+// RestockCancelledOrder hands the stock inventory deducted for an order back when the order was cancelled
+// before it could be confirmed. Confirmed orders keep their stock, so redelivered events change nothing.
+// It reports whether inventory has been asked to return the stock.
+func (o *Order) RestockCancelledOrder(ctx context.Context, orderID uint64) (bool, error) {
+	order, err := o.repo.GetWithFilter(ctx, domain.OrderFilter{ID: &orderID})
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if order.Status != domain.StatusCancelled {
+		return false, nil
+	}
+
+	if err = o.inventoryClient.RestockOrder(ctx, orderID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (o *Order) resolvePending(ctx context.Context, orderID uint64, status domain.OrderStatus, reason string) (bool, error) {
+	pending := domain.StatusPending
+	now := time.Now()
+	update := domain.OrderUpdateData{Status: &status, UpdatedAt: &now}
+	if reason != "" {
+		update.StatusReason = &reason
+	}
+
+	err := o.repo.Update(ctx, domain.OrderFilter{ID: &orderID, Status: &pending}, update)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
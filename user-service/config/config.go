@@ -1,6 +1,7 @@
 package config
 
 import (
+	"github.com/BeksultanSE/Assignment1-user/pkg/hashing"
 	"github.com/BeksultanSE/Assignment1-user/pkg/mongo"
 	"github.com/caarlos0/env/v10"
 	"github.com/joho/godotenv"
@@ -12,9 +13,20 @@ import (
 
 type (
 	Config struct {
-		Mongo   mongo.Config
-		Server  Server
-		Version string `env:"VERSION"`
+		Mongo             mongo.Config
+		Hashing           hashing.Config
+		Server            Server
+		Token             Token
+		PasswordReset     PasswordReset
+		EmailVerification EmailVerification
+		Lockout           Lockout
+		PasswordPolicy    PasswordPolicy
+		TwoFactor         TwoFactor
+		Notifier          Notifier
+		Bootstrap         Bootstrap
+		Services          Microservices
+		Brokers           []string `env:"BROKERS"`
+		Version           string   `env:"VERSION"`
 	}
 
 	Server struct {
@@ -32,8 +44,73 @@ type (
 	}
 
 	GRPCServer struct {
-		Port    int           `env:"GRPC_PORT,required"`
-		Timeout time.Duration `env:"GRPC_TIMEOUT" envDefault:"30s"`
+		Port    int               `env:"GRPC_PORT,required"`
+		Timeout time.Duration     `env:"GRPC_TIMEOUT" envDefault:"30s"`
+		Callers map[string]string `env:"GRPC_CALLERS,required"` // service name to token of every service allowed to call, e.g. "api-gateway:<token>,order-service:<token>"
+	}
+
+	// Token configuration for issued JWTs
+	Token struct {
+		PrivateKeyPath string        `env:"TOKEN_PRIVATE_KEY_PATH"` // PEM encoded PKCS8 ed25519 key, generated on startup if empty
+		Issuer         string        `env:"TOKEN_ISSUER" envDefault:"user-service"`
+		AccessTTL      time.Duration `env:"TOKEN_ACCESS_TTL" envDefault:"15m"`
+		RefreshTTL     time.Duration `env:"TOKEN_REFRESH_TTL" envDefault:"720h"`
+		TwoFactorTTL   time.Duration `env:"TOKEN_TWO_FACTOR_TTL" envDefault:"5m"` // time to enter the TOTP code after the password
+	}
+
+	// PasswordReset configuration for the forgotten password flow
+	PasswordReset struct {
+		TokenTTL time.Duration `env:"PASSWORD_RESET_TOKEN_TTL" envDefault:"30m"`
+	}
+
+	// EmailVerification configuration for confirming the address of new accounts
+	EmailVerification struct {
+		TokenTTL time.Duration `env:"EMAIL_VERIFICATION_TOKEN_TTL" envDefault:"24h"`
+	}
+
+	// Lockout configuration for brute-force protection of logins
+	Lockout struct {
+		MaxAccountFailures int           `env:"LOCKOUT_MAX_ACCOUNT_FAILURES" envDefault:"5"`
+		MaxIPFailures      int           `env:"LOCKOUT_MAX_IP_FAILURES" envDefault:"20"`
+		FailureWindow      time.Duration `env:"LOCKOUT_FAILURE_WINDOW" envDefault:"15m"`
+		BaseDuration       time.Duration `env:"LOCKOUT_BASE_DURATION" envDefault:"1m"` // doubled with every consecutive lockout
+		MaxDuration        time.Duration `env:"LOCKOUT_MAX_DURATION" envDefault:"1h"`
+		ResetAfter         time.Duration `env:"LOCKOUT_RESET_AFTER" envDefault:"24h"`
+	}
+
+	// PasswordPolicy configuration for new passwords set on registration, change and reset
+	PasswordPolicy struct {
+		MinLength           int    `env:"PASSWORD_MIN_LENGTH" envDefault:"10"`
+		MaxLength           int    `env:"PASSWORD_MAX_LENGTH" envDefault:"128"`
+		MinCharacterClasses int    `env:"PASSWORD_MIN_CHARACTER_CLASSES" envDefault:"3"`   // of lowercase, uppercase, digits and symbols
+		RejectPersonalInfo  bool   `env:"PASSWORD_REJECT_PERSONAL_INFO" envDefault:"true"` // reject passwords containing the name or email
+		BreachedListPath    string `env:"PASSWORD_BREACHED_LIST_PATH"`                     // SHA1 list file or HIBP range directory, disabled if empty
+		BreachedMinCount    int    `env:"PASSWORD_BREACHED_MIN_COUNT" envDefault:"1"`      // breach occurrences needed to reject a password
+	}
+
+	// TwoFactor configuration for TOTP authenticator apps
+	TwoFactor struct {
+		Issuer string `env:"TWO_FACTOR_ISSUER" envDefault:"Motorcycle Store"` // account label shown in authenticator apps
+	}
+
+	// Notifier configuration, notifications are written to the outbox file or to the log if it is empty
+	Notifier struct {
+		OutboxPath string `env:"NOTIFIER_OUTBOX_PATH"`
+	}
+
+	// Bootstrap configuration for setting up a new store
+	Bootstrap struct {
+		AdminEmail string `env:"BOOTSTRAP_ADMIN_EMAIL"` // verified account promoted to admin on startup, disabled if empty
+	}
+
+	Microservices struct {
+		OrderService ServiceConfig `envPrefix:"ORDER_SERVICE_"`
+		Token        string        `env:"SERVICE_TOKEN,required"` // presented to the services called, has to be listed in their GRPC_CALLERS
+	}
+
+	ServiceConfig struct {
+		Host string `env:"HOST,required"`
+		Port int    `env:"PORT,required"`
 	}
 )
 
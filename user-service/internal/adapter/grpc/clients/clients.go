@@ -0,0 +1,66 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/config"
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"log"
+)
+
+// serviceName identifies this service to the services it calls
+const serviceName = "user-service"
+
+type Clients struct {
+	Order proto.OrderServiceClient
+	conns []*grpc.ClientConn
+}
+
+func NewClients(cfg *config.Config) (*Clients, error) {
+	clients := &Clients{}
+	credentials := serviceCredentials{name: serviceName, token: cfg.Services.Token}
+
+	// Order Service Client
+	orderTarget := fmt.Sprintf("%s:%d", cfg.Services.OrderService.Host, cfg.Services.OrderService.Port)
+	orderConn, err := grpc.NewClient(
+		orderTarget,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(credentials),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to order service: %w", err)
+	}
+	clients.Order = proto.NewOrderServiceClient(orderConn)
+	clients.conns = append(clients.conns, orderConn)
+
+	log.Println("Successfully initialized gRPC clients for all services")
+	return clients, nil
+}
+
+func (c *Clients) Close() {
+	for _, conn := range c.conns {
+		if err := conn.Close(); err != nil {
+			log.Printf("Failed to close gRPC connection: %v", err)
+		}
+	}
+}
+
+// serviceCredentials identify this service to the services it calls, they check the token against their GRPC_CALLERS
+type serviceCredentials struct {
+	name  string
+	token string
+}
+
+func (c serviceCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		"x-service-name":  c.name,
+		"x-service-token": c.token,
+	}, nil
+}
+
+// RequireTransportSecurity allows the token on the plaintext connections of the internal network
+func (c serviceCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,119 @@
+package clients
+
+import (
+	"context"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc/metadata"
+)
+
+// orderPageSize is the number of orders fetched per ListOrders call while collecting the history
+const orderPageSize = 100
+
+// forwardedMetadata is the caller identity attached by the api-gateway
+var forwardedMetadata = []string{"x-user-id", "x-user-role", "x-client-ip"}
+
+type OrderClient struct {
+	client proto.OrderServiceClient
+}
+
+func NewOrderClient(client proto.OrderServiceClient) *OrderClient {
+	return &OrderClient{client: client}
+}
+
+// ListUserOrders fetches the complete order history of the user page by page. Pages follow the page
+// tokens and skip counting, so orders placed meanwhile neither repeat nor get lost.
+func (c *OrderClient) ListUserOrders(ctx context.Context, userID uint64) ([]domain.Order, error) {
+	ctx = forwardCaller(ctx)
+
+	var orders []domain.Order
+	pageToken := ""
+	for {
+		resp, err := c.client.ListOrders(ctx, &proto.ListOrdersRequest{
+			UserId:    userID,
+			Limit:     orderPageSize,
+			PageToken: pageToken,
+			SkipTotal: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, order := range resp.Orders {
+			orders = append(orders, toDomainOrder(order))
+		}
+		if resp.NextPageToken == "" {
+			return orders, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// PseudonymizeUserOrders detaches the orders from the user and returns how many were affected. Order-service
+// only accepts it from user-service itself, so no caller identity is forwarded.
+func (c *OrderClient) PseudonymizeUserOrders(ctx context.Context, userID uint64) (int64, error) {
+	resp, err := c.client.PseudonymizeUserOrders(ctx, &proto.PseudonymizeUserOrdersRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Orders, nil
+}
+
+// forwardCaller passes the caller identity attached by the api-gateway on to order-service
+func forwardCaller(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	forwarded := metadata.MD{}
+	for _, key := range forwardedMetadata {
+		if values := md.Get(key); len(values) > 0 {
+			forwarded.Set(key, values...)
+		}
+	}
+	return metadata.NewOutgoingContext(ctx, forwarded)
+}
+
+func toDomainOrder(order *proto.OrderResponse) domain.Order {
+	items := make([]domain.OrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, domain.OrderItem{
+			ProductID:  item.ProductId,
+			Name:       item.Name,
+			Price:      item.Price,
+			Quantity:   item.Quantity,
+			TotalPrice: item.TotalPrice,
+		})
+	}
+
+	return domain.Order{
+		ID:              order.OrderId,
+		Status:          order.Status,
+		Items:           items,
+		TotalAmount:     order.TotalAmount,
+		ShippingAddress: toDomainOrderAddress(order.ShippingAddress),
+		BillingAddress:  toDomainOrderAddress(order.BillingAddress),
+		CreatedAt:       order.CreatedAt,
+		UpdatedAt:       order.UpdatedAt,
+	}
+}
+
+func toDomainOrderAddress(address *proto.OrderAddress) domain.OrderAddress {
+	if address == nil {
+		return domain.OrderAddress{}
+	}
+
+	return domain.OrderAddress{
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		Region:        address.Region,
+		PostalCode:    address.PostalCode,
+		Country:       address.Country,
+	}
+}
@@ -0,0 +1,216 @@
+package dto
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// CreateAddressRequestDTO maps to proto.CreateAddressRequest
+type CreateAddressRequestDTO struct {
+	UserID          uint64
+	Label           string
+	RecipientName   string
+	Phone           string
+	Line1           string
+	Line2           string
+	City            string
+	Region          string
+	PostalCode      string
+	Country         string
+	DefaultShipping bool
+	DefaultBilling  bool
+}
+
+// ValidateCreateAddressRequest ensures the fields needed for delivery are present
+func (dto *CreateAddressRequestDTO) ValidateCreateAddressRequest() error {
+	if dto.UserID == 0 {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if dto.RecipientName == "" {
+		return status.Error(codes.InvalidArgument, "recipient_name is required")
+	}
+	if dto.Line1 == "" {
+		return status.Error(codes.InvalidArgument, "line1 is required")
+	}
+	if dto.City == "" {
+		return status.Error(codes.InvalidArgument, "city is required")
+	}
+	if dto.PostalCode == "" {
+		return status.Error(codes.InvalidArgument, "postal_code is required")
+	}
+	return validateCountry(dto.Country)
+}
+
+// ToDomainAddress converts DTO to domain.Address
+func (dto *CreateAddressRequestDTO) ToDomainAddress() domain.Address {
+	return domain.Address{
+		UserID:          dto.UserID,
+		Label:           dto.Label,
+		RecipientName:   dto.RecipientName,
+		Phone:           dto.Phone,
+		Line1:           dto.Line1,
+		Line2:           dto.Line2,
+		City:            dto.City,
+		Region:          dto.Region,
+		PostalCode:      dto.PostalCode,
+		Country:         dto.Country,
+		DefaultShipping: dto.DefaultShipping,
+		DefaultBilling:  dto.DefaultBilling,
+	}
+}
+
+// FromCreateAddressRequestProto converts proto.CreateAddressRequest to DTO
+func FromCreateAddressRequestProto(req *proto.CreateAddressRequest) CreateAddressRequestDTO {
+	return CreateAddressRequestDTO{
+		UserID:          req.UserId,
+		Label:           strings.TrimSpace(req.Label),
+		RecipientName:   strings.TrimSpace(req.RecipientName),
+		Phone:           strings.TrimSpace(req.Phone),
+		Line1:           strings.TrimSpace(req.Line1),
+		Line2:           strings.TrimSpace(req.Line2),
+		City:            strings.TrimSpace(req.City),
+		Region:          strings.TrimSpace(req.Region),
+		PostalCode:      strings.TrimSpace(req.PostalCode),
+		Country:         strings.ToUpper(strings.TrimSpace(req.Country)),
+		DefaultShipping: req.DefaultShipping,
+		DefaultBilling:  req.DefaultBilling,
+	}
+}
+
+// AddressRequestDTO maps to proto.AddressRequest
+type AddressRequestDTO struct {
+	UserID    uint64
+	AddressID uint64
+}
+
+// ValidateAddressRequest ensures required fields are present
+func (dto *AddressRequestDTO) ValidateAddressRequest() error {
+	if dto.UserID == 0 {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if dto.AddressID == 0 {
+		return status.Error(codes.InvalidArgument, "address_id is required")
+	}
+	return nil
+}
+
+// FromAddressRequestProto converts proto.AddressRequest to DTO
+func FromAddressRequestProto(req *proto.AddressRequest) AddressRequestDTO {
+	return AddressRequestDTO{
+		UserID:    req.UserId,
+		AddressID: req.AddressId,
+	}
+}
+
+// UpdateAddressRequestDTO maps to proto.UpdateAddressRequest
+type UpdateAddressRequestDTO struct {
+	UserID    uint64
+	AddressID uint64
+	Update    domain.AddressUpdate
+}
+
+// ValidateUpdateAddressRequest ensures something is changed and required fields are not cleared
+func (dto *UpdateAddressRequestDTO) ValidateUpdateAddressRequest() error {
+	if dto.UserID == 0 {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if dto.AddressID == 0 {
+		return status.Error(codes.InvalidArgument, "address_id is required")
+	}
+
+	u := dto.Update
+	if u.Label == nil && u.RecipientName == nil && u.Phone == nil && u.Line1 == nil && u.Line2 == nil &&
+		u.City == nil && u.Region == nil && u.PostalCode == nil && u.Country == nil &&
+		u.DefaultShipping == nil && u.DefaultBilling == nil {
+		return status.Error(codes.InvalidArgument, "at least one field is required")
+	}
+	if u.RecipientName != nil && *u.RecipientName == "" {
+		return status.Error(codes.InvalidArgument, "recipient_name cannot be empty")
+	}
+	if u.Line1 != nil && *u.Line1 == "" {
+		return status.Error(codes.InvalidArgument, "line1 cannot be empty")
+	}
+	if u.City != nil && *u.City == "" {
+		return status.Error(codes.InvalidArgument, "city cannot be empty")
+	}
+	if u.PostalCode != nil && *u.PostalCode == "" {
+		return status.Error(codes.InvalidArgument, "postal_code cannot be empty")
+	}
+	if u.Country != nil {
+		return validateCountry(*u.Country)
+	}
+	return nil
+}
+
+// FromUpdateAddressRequestProto converts proto.UpdateAddressRequest to DTO
+func FromUpdateAddressRequestProto(req *proto.UpdateAddressRequest) UpdateAddressRequestDTO {
+	update := domain.AddressUpdate{
+		Label:           trimmed(req.Label),
+		RecipientName:   trimmed(req.RecipientName),
+		Phone:           trimmed(req.Phone),
+		Line1:           trimmed(req.Line1),
+		Line2:           trimmed(req.Line2),
+		City:            trimmed(req.City),
+		Region:          trimmed(req.Region),
+		PostalCode:      trimmed(req.PostalCode),
+		Country:         trimmed(req.Country),
+		DefaultShipping: req.DefaultShipping,
+		DefaultBilling:  req.DefaultBilling,
+	}
+	if update.Country != nil {
+		country := strings.ToUpper(*update.Country)
+		update.Country = &country
+	}
+
+	return UpdateAddressRequestDTO{
+		UserID:    req.UserId,
+		AddressID: req.AddressId,
+		Update:    update,
+	}
+}
+
+// ToProtoAddress converts domain.Address to proto.Address
+func ToProtoAddress(address domain.Address) *proto.Address {
+	return &proto.Address{
+		AddressId:       address.ID,
+		UserId:          address.UserID,
+		Label:           address.Label,
+		RecipientName:   address.RecipientName,
+		Phone:           address.Phone,
+		Line1:           address.Line1,
+		Line2:           address.Line2,
+		City:            address.City,
+		Region:          address.Region,
+		PostalCode:      address.PostalCode,
+		Country:         address.Country,
+		DefaultShipping: address.DefaultShipping,
+		DefaultBilling:  address.DefaultBilling,
+	}
+}
+
+// ToProtoListAddressesResponse converts domain addresses to proto.ListAddressesResponse
+func ToProtoListAddressesResponse(addresses []domain.Address) *proto.ListAddressesResponse {
+	protoAddresses := make([]*proto.Address, 0, len(addresses))
+	for _, address := range addresses {
+		protoAddresses = append(protoAddresses, ToProtoAddress(address))
+	}
+	return &proto.ListAddressesResponse{Addresses: protoAddresses}
+}
+
+func validateCountry(country string) error {
+	if len(country) != 2 || strings.Trim(country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return status.Error(codes.InvalidArgument, "country must be a two-letter ISO 3166-1 code")
+	}
+	return nil
+}
+
+func trimmed(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	v := strings.TrimSpace(*value)
+	return &v
+}
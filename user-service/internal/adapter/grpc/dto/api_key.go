@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateAPIKeyRequestDTO maps to proto.CreateAPIKeyRequest
+type CreateAPIKeyRequestDTO struct {
+	UserID uint64
+	Name   string
+	Role   string
+}
+
+// ValidateCreateAPIKeyRequest ensures required fields are present
+func (dto *CreateAPIKeyRequestDTO) ValidateCreateAPIKeyRequest() error {
+	if dto.UserID == 0 {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if dto.Name == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+	return nil
+}
+
+// ToDomainAPIKey converts DTO to domain.APIKey
+func (dto *CreateAPIKeyRequestDTO) ToDomainAPIKey() domain.APIKey {
+	return domain.APIKey{
+		Name:    dto.Name,
+		OwnerID: dto.UserID,
+		Role:    domain.Role(dto.Role),
+	}
+}
+
+// FromCreateAPIKeyRequestProto converts proto.CreateAPIKeyRequest to DTO
+func FromCreateAPIKeyRequestProto(req *proto.CreateAPIKeyRequest) CreateAPIKeyRequestDTO {
+	return CreateAPIKeyRequestDTO{
+		UserID: req.UserId,
+		Name:   req.Name,
+		Role:   req.Role,
+	}
+}
+
+// RevokeAPIKeyRequestDTO maps to proto.RevokeAPIKeyRequest
+type RevokeAPIKeyRequestDTO struct {
+	UserID uint64
+	KeyID  uint64
+}
+
+// ValidateRevokeAPIKeyRequest ensures required fields are present
+func (dto *RevokeAPIKeyRequestDTO) ValidateRevokeAPIKeyRequest() error {
+	if dto.UserID == 0 {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if dto.KeyID == 0 {
+		return status.Error(codes.InvalidArgument, "key_id is required")
+	}
+	return nil
+}
+
+// FromRevokeAPIKeyRequestProto converts proto.RevokeAPIKeyRequest to DTO
+func FromRevokeAPIKeyRequestProto(req *proto.RevokeAPIKeyRequest) RevokeAPIKeyRequestDTO {
+	return RevokeAPIKeyRequestDTO{
+		UserID: req.UserId,
+		KeyID:  req.KeyId,
+	}
+}
+
+// ValidateAPIKeyRequestDTO maps to proto.ValidateAPIKeyRequest
+type ValidateAPIKeyRequestDTO struct {
+	APIKey string
+}
+
+// ValidateValidateAPIKeyRequest ensures required fields are present
+func (dto *ValidateAPIKeyRequestDTO) ValidateValidateAPIKeyRequest() error {
+	if dto.APIKey == "" {
+		return status.Error(codes.InvalidArgument, "api_key is required")
+	}
+	return nil
+}
+
+// FromValidateAPIKeyRequestProto converts proto.ValidateAPIKeyRequest to DTO
+func FromValidateAPIKeyRequestProto(req *proto.ValidateAPIKeyRequest) ValidateAPIKeyRequestDTO {
+	return ValidateAPIKeyRequestDTO{
+		APIKey: req.ApiKey,
+	}
+}
+
+// ToProtoAPIKey converts domain.APIKey to proto.APIKey
+func ToProtoAPIKey(key domain.APIKey) *proto.APIKey {
+	var lastUsedAt int64
+	if !key.LastUsedAt.IsZero() {
+		lastUsedAt = key.LastUsedAt.Unix()
+	}
+
+	return &proto.APIKey{
+		KeyId:      key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		UserId:     key.OwnerID,
+		Role:       string(key.Role),
+		CreatedAt:  key.CreatedAt.Unix(),
+		LastUsedAt: lastUsedAt,
+		Revoked:    key.Revoked(),
+	}
+}
+
+// ToProtoListAPIKeysResponse converts domain API keys to proto.ListAPIKeysResponse
+func ToProtoListAPIKeysResponse(keys []domain.APIKey) *proto.ListAPIKeysResponse {
+	protoKeys := make([]*proto.APIKey, 0, len(keys))
+	for _, key := range keys {
+		protoKeys = append(protoKeys, ToProtoAPIKey(key))
+	}
+	return &proto.ListAPIKeysResponse{Keys: protoKeys}
+}
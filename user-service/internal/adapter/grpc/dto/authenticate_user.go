@@ -9,8 +9,9 @@ import (
 
 // AuthenticateUserRequestDTO maps to proto.AuthenticateUserRequest
 type AuthenticateUserRequestDTO struct {
-	Email    string
-	Password string
+	Email         string
+	Password      string
+	TwoFactorCode string
 }
 
 // Validate ensures required fields are present
@@ -35,8 +36,9 @@ func (dto *AuthenticateUserRequestDTO) ToDomainAuthRequest() domain.User {
 // FromAuthenticateUserRequestProto converts proto.AuthenticateUserRequest to DTO
 func FromAuthenticateUserRequestProto(req *proto.AuthRequest) AuthenticateUserRequestDTO {
 	return AuthenticateUserRequestDTO{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:         req.Email,
+		Password:      req.Password,
+		TwoFactorCode: req.TwoFactorCode,
 	}
 }
 
@@ -44,6 +46,7 @@ func FromAuthenticateUserRequestProto(req *proto.AuthRequest) AuthenticateUserRe
 type AuthenticateUserResponseDTO struct {
 	UserID        uint64
 	Name          string
+	Role          string
 	Authenticated bool
 }
 
@@ -52,6 +55,7 @@ func (dto *AuthenticateUserResponseDTO) ToProtoAuthResponse() *proto.AuthRespons
 	return &proto.AuthResponse{
 		UserId:        dto.UserID,
 		Authenticated: dto.Authenticated,
+		Role:          dto.Role,
 	}
 }
 
@@ -60,6 +64,7 @@ func FromAuthenticateUserDomain(user domain.User) AuthenticateUserResponseDTO {
 	return AuthenticateUserResponseDTO{
 		UserID:        user.ID,
 		Name:          user.Name,
+		Role:          string(user.Role),
 		Authenticated: true, // Only called on successful authentication
 	}
 }
@@ -0,0 +1,226 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"time"
+)
+
+const dataExportContentType = "application/json"
+
+// dataExportArchive is the layout of the downloadable archive. Credentials such as password hashes,
+// two-factor secrets and key hashes are left out on purpose.
+type dataExportArchive struct {
+	GeneratedAt time.Time           `json:"generated_at"`
+	Profile     dataExportProfile   `json:"profile"`
+	Addresses   []dataExportAddress `json:"addresses"`
+	Sessions    []dataExportSession `json:"sessions"`
+	APIKeys     []dataExportAPIKey  `json:"api_keys"`
+	Orders      []dataExportOrder   `json:"orders"`
+}
+
+type dataExportProfile struct {
+	UserID           uint64     `json:"user_id"`
+	Name             string     `json:"name"`
+	Email            string     `json:"email"`
+	Role             string     `json:"role"`
+	EmailVerified    bool       `json:"email_verified"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	ErasedAt         *time.Time `json:"erased_at,omitempty"`
+}
+
+type dataExportAddress struct {
+	AddressID       uint64    `json:"address_id"`
+	Label           string    `json:"label,omitempty"`
+	RecipientName   string    `json:"recipient_name"`
+	Phone           string    `json:"phone,omitempty"`
+	Line1           string    `json:"line1"`
+	Line2           string    `json:"line2,omitempty"`
+	City            string    `json:"city"`
+	Region          string    `json:"region,omitempty"`
+	PostalCode      string    `json:"postal_code"`
+	Country         string    `json:"country"`
+	DefaultShipping bool      `json:"default_shipping"`
+	DefaultBilling  bool      `json:"default_billing"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type dataExportSession struct {
+	SessionID  string     `json:"session_id"`
+	UserAgent  string     `json:"user_agent,omitempty"`
+	IP         string     `json:"ip,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type dataExportAPIKey struct {
+	KeyID      uint64     `json:"key_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Role       string     `json:"role,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type dataExportOrder struct {
+	OrderID         uint64                  `json:"order_id"`
+	Status          string                  `json:"status"`
+	Items           []dataExportOrderItem   `json:"items"`
+	TotalAmount     float64                 `json:"total_amount"`
+	ShippingAddress *dataExportOrderAddress `json:"shipping_address,omitempty"`
+	BillingAddress  *dataExportOrderAddress `json:"billing_address,omitempty"`
+	CreatedAt       string                  `json:"created_at"`
+	UpdatedAt       string                  `json:"updated_at"`
+}
+
+type dataExportOrderItem struct {
+	ProductID  uint64  `json:"product_id"`
+	Name       string  `json:"name"`
+	Price      float64 `json:"price"`
+	Quantity   uint64  `json:"quantity"`
+	TotalPrice float64 `json:"total_price"`
+}
+
+type dataExportOrderAddress struct {
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone,omitempty"`
+	Line1         string `json:"line1"`
+	Line2         string `json:"line2,omitempty"`
+	City          string `json:"city"`
+	Region        string `json:"region,omitempty"`
+	PostalCode    string `json:"postal_code"`
+	Country       string `json:"country"`
+}
+
+// ToProtoDataExport renders the export as an indented JSON archive
+func ToProtoDataExport(export domain.DataExport) (*proto.DataExport, error) {
+	archive := dataExportArchive{
+		GeneratedAt: export.GeneratedAt.UTC(),
+		Profile: dataExportProfile{
+			UserID:           export.User.ID,
+			Name:             export.User.Name,
+			Email:            export.User.Email,
+			Role:             string(export.User.Role),
+			EmailVerified:    export.User.EmailVerified,
+			TwoFactorEnabled: export.User.TwoFactor.Enabled,
+			CreatedAt:        export.User.CreatedAt,
+			UpdatedAt:        export.User.UpdatedAt,
+			ErasedAt:         optionalTime(export.User.ErasedAt),
+		},
+		Addresses: make([]dataExportAddress, 0, len(export.Addresses)),
+		Sessions:  make([]dataExportSession, 0, len(export.Sessions)),
+		APIKeys:   make([]dataExportAPIKey, 0, len(export.APIKeys)),
+		Orders:    make([]dataExportOrder, 0, len(export.Orders)),
+	}
+
+	for _, address := range export.Addresses {
+		archive.Addresses = append(archive.Addresses, dataExportAddress{
+			AddressID:       address.ID,
+			Label:           address.Label,
+			RecipientName:   address.RecipientName,
+			Phone:           address.Phone,
+			Line1:           address.Line1,
+			Line2:           address.Line2,
+			City:            address.City,
+			Region:          address.Region,
+			PostalCode:      address.PostalCode,
+			Country:         address.Country,
+			DefaultShipping: address.DefaultShipping,
+			DefaultBilling:  address.DefaultBilling,
+			CreatedAt:       address.CreatedAt,
+			UpdatedAt:       address.UpdatedAt,
+		})
+	}
+
+	for _, session := range export.Sessions {
+		archive.Sessions = append(archive.Sessions, dataExportSession{
+			SessionID:  session.ID,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+			RevokedAt:  optionalTime(session.RevokedAt),
+		})
+	}
+
+	for _, key := range export.APIKeys {
+		archive.APIKeys = append(archive.APIKeys, dataExportAPIKey{
+			KeyID:      key.ID,
+			Name:       key.Name,
+			Prefix:     key.Prefix,
+			Role:       string(key.Role),
+			CreatedAt:  key.CreatedAt,
+			LastUsedAt: optionalTime(key.LastUsedAt),
+			RevokedAt:  optionalTime(key.RevokedAt),
+		})
+	}
+
+	for _, order := range export.Orders {
+		items := make([]dataExportOrderItem, 0, len(order.Items))
+		for _, item := range order.Items {
+			items = append(items, dataExportOrderItem{
+				ProductID:  item.ProductID,
+				Name:       item.Name,
+				Price:      item.Price,
+				Quantity:   item.Quantity,
+				TotalPrice: item.TotalPrice,
+			})
+		}
+
+		archive.Orders = append(archive.Orders, dataExportOrder{
+			OrderID:         order.ID,
+			Status:          order.Status,
+			Items:           items,
+			TotalAmount:     order.TotalAmount,
+			ShippingAddress: toDataExportOrderAddress(order.ShippingAddress),
+			BillingAddress:  toDataExportOrderAddress(order.BillingAddress),
+			CreatedAt:       order.CreatedAt,
+			UpdatedAt:       order.UpdatedAt,
+		})
+	}
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode data export: %w", err)
+	}
+
+	return &proto.DataExport{
+		Archive:     data,
+		Filename:    fmt.Sprintf("user-%d-data-%s.json", export.User.ID, export.GeneratedAt.UTC().Format("20060102T150405Z")),
+		ContentType: dataExportContentType,
+	}, nil
+}
+
+// toDataExportOrderAddress leaves out addresses of orders placed before address snapshots were taken
+func toDataExportOrderAddress(address domain.OrderAddress) *dataExportOrderAddress {
+	if address == (domain.OrderAddress{}) {
+		return nil
+	}
+
+	return &dataExportOrderAddress{
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		City:          address.City,
+		Region:        address.Region,
+		PostalCode:    address.PostalCode,
+		Country:       address.Country,
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,27 @@
+package dto
+
+import (
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VerifyEmailRequestDTO maps to proto.VerifyEmailRequest
+type VerifyEmailRequestDTO struct {
+	Token string
+}
+
+// ValidateVerifyEmailRequest ensures required fields are present
+func (dto *VerifyEmailRequestDTO) ValidateVerifyEmailRequest() error {
+	if dto.Token == "" {
+		return status.Error(codes.InvalidArgument, "token is required")
+	}
+	return nil
+}
+
+// FromVerifyEmailRequestProto converts proto.VerifyEmailRequest to DTO
+func FromVerifyEmailRequestProto(req *proto.VerifyEmailRequest) VerifyEmailRequestDTO {
+	return VerifyEmailRequestDTO{
+		Token: req.Token,
+	}
+}
@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"time"
+)
+
+const (
+	defaultUsersPageLimit = 10
+	maxUsersPageLimit     = 100
+)
+
+// ListUsersRequestDTO maps to proto.ListUsersRequest
+type ListUsersRequestDTO struct {
+	Search      *string
+	Role        *string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	SortBy      string
+	SortDesc    bool
+	Page        int64
+	Limit       int64
+}
+
+// FromListUsersRequestProto converts proto.ListUsersRequest to DTO, applying paging defaults
+func FromListUsersRequestProto(req *proto.ListUsersRequest) ListUsersRequestDTO {
+	requestDTO := ListUsersRequestDTO{
+		SortBy:   strings.ToLower(strings.TrimSpace(req.SortBy)),
+		SortDesc: req.SortDesc,
+		Page:     req.Page,
+		Limit:    req.Limit,
+	}
+
+	if req.Search != nil {
+		search := strings.TrimSpace(req.GetSearch())
+		if search != "" {
+			requestDTO.Search = &search
+		}
+	}
+	if req.Role != nil {
+		role := req.GetRole()
+		requestDTO.Role = &role
+	}
+	if req.CreatedFrom != nil {
+		from := time.Unix(req.GetCreatedFrom(), 0).UTC()
+		requestDTO.CreatedFrom = &from
+	}
+	if req.CreatedTo != nil {
+		to := time.Unix(req.GetCreatedTo(), 0).UTC()
+		requestDTO.CreatedTo = &to
+	}
+
+	if requestDTO.SortBy == "" {
+		requestDTO.SortBy = string(domain.UserSortByID)
+	}
+	if requestDTO.Page <= 0 {
+		requestDTO.Page = 1
+	}
+	if requestDTO.Limit <= 0 {
+		requestDTO.Limit = defaultUsersPageLimit
+	}
+
+	return requestDTO
+}
+
+// ValidateListUsersRequest checks sorting, role and date range parameters
+func (dto *ListUsersRequestDTO) ValidateListUsersRequest() error {
+	if !domain.UserSortField(dto.SortBy).Valid() {
+		return status.Error(codes.InvalidArgument, "sort_by must be one of: id, name, email, created_at")
+	}
+	if dto.Role != nil && !domain.Role(*dto.Role).Valid() {
+		return status.Error(codes.InvalidArgument, "role must be one of: customer, sales_staff, warehouse, admin")
+	}
+	if dto.CreatedFrom != nil && dto.CreatedTo != nil && dto.CreatedFrom.After(*dto.CreatedTo) {
+		return status.Error(codes.InvalidArgument, "created_from must not be after created_to")
+	}
+	if dto.Limit > maxUsersPageLimit {
+		return status.Errorf(codes.InvalidArgument, "limit must not exceed %d", maxUsersPageLimit)
+	}
+	return nil
+}
+
+// ToDomainFilter converts DTO to domain.UserFilter
+func (dto *ListUsersRequestDTO) ToDomainFilter() domain.UserFilter {
+	filter := domain.UserFilter{
+		Search:      dto.Search,
+		CreatedFrom: dto.CreatedFrom,
+		CreatedTo:   dto.CreatedTo,
+	}
+	if dto.Role != nil {
+		role := domain.Role(*dto.Role)
+		filter.Role = &role
+	}
+	return filter
+}
+
+// ToDomainSort converts DTO sorting parameters to domain.UserSort
+func (dto *ListUsersRequestDTO) ToDomainSort() domain.UserSort {
+	return domain.UserSort{
+		Field:      domain.UserSortField(dto.SortBy),
+		Descending: dto.SortDesc,
+	}
+}
+
+// ToProtoListUsersResponse converts a page of users to proto.ListUsersResponse
+func ToProtoListUsersResponse(users []domain.User, total int) *proto.ListUsersResponse {
+	profiles := make([]*proto.UserProfile, 0, len(users))
+	for _, user := range users {
+		profileDTO := FromUserProfileDomain(user)
+		profiles = append(profiles, profileDTO.ToProtoUserProfile())
+	}
+	return &proto.ListUsersResponse{
+		Users: profiles,
+		Total: int64(total),
+	}
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"errors"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PasswordPolicyStatus converts a password rejected by the policy into an InvalidArgument status carrying
+// a BadRequest detail with one field violation per broken rule. Returns nil for any other error.
+func PasswordPolicyStatus(err error, field string) error {
+	var policyErr domain.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range policyErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: field + " " + violation.Message,
+			Reason:      violation.Rule,
+		})
+	}
+
+	st, detailErr := status.New(codes.InvalidArgument, field+" does not meet the password policy").WithDetails(badRequest)
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, policyErr.Error())
+	}
+	return st.Err()
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RequestPasswordResetRequestDTO maps to proto.RequestPasswordResetRequest
+type RequestPasswordResetRequestDTO struct {
+	Email string
+}
+
+// ValidateRequestPasswordResetRequest ensures required fields are present
+func (dto *RequestPasswordResetRequestDTO) ValidateRequestPasswordResetRequest() error {
+	if dto.Email == "" {
+		return status.Error(codes.InvalidArgument, "email is required")
+	}
+	return nil
+}
+
+// FromRequestPasswordResetRequestProto converts proto.RequestPasswordResetRequest to DTO
+func FromRequestPasswordResetRequestProto(req *proto.RequestPasswordResetRequest) RequestPasswordResetRequestDTO {
+	return RequestPasswordResetRequestDTO{
+		Email: req.Email,
+	}
+}
+
+// ResetPasswordRequestDTO maps to proto.ResetPasswordRequest
+type ResetPasswordRequestDTO struct {
+	Token       string
+	NewPassword string
+}
+
+// ValidateResetPasswordRequest ensures required fields are present
+func (dto *ResetPasswordRequestDTO) ValidateResetPasswordRequest() error {
+	if dto.Token == "" {
+		return status.Error(codes.InvalidArgument, "token is required")
+	}
+	if dto.NewPassword == "" {
+		return status.Error(codes.InvalidArgument, "new_password is required")
+	}
+	return nil
+}
+
+// FromResetPasswordRequestProto converts proto.ResetPasswordRequest to DTO
+func FromResetPasswordRequestProto(req *proto.ResetPasswordRequest) ResetPasswordRequestDTO {
+	return ResetPasswordRequestDTO{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+	}
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeSessionRequestDTO maps to proto.RevokeSessionRequest
+type RevokeSessionRequestDTO struct {
+	UserID    uint64
+	SessionID string
+}
+
+// ValidateRevokeSessionRequest ensures required fields are present
+func (dto *RevokeSessionRequestDTO) ValidateRevokeSessionRequest() error {
+	if dto.UserID == 0 {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if dto.SessionID == "" {
+		return status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	return nil
+}
+
+// FromRevokeSessionRequestProto converts proto.RevokeSessionRequest to DTO
+func FromRevokeSessionRequestProto(req *proto.RevokeSessionRequest) RevokeSessionRequestDTO {
+	return RevokeSessionRequestDTO{
+		UserID:    req.UserId,
+		SessionID: req.SessionId,
+	}
+}
+
+// RevokeAllSessionsRequestDTO maps to proto.RevokeAllSessionsRequest
+type RevokeAllSessionsRequestDTO struct {
+	UserID      uint64
+	KeepCurrent bool
+}
+
+// ValidateRevokeAllSessionsRequest ensures required fields are present
+func (dto *RevokeAllSessionsRequestDTO) ValidateRevokeAllSessionsRequest() error {
+	if dto.UserID == 0 {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	return nil
+}
+
+// FromRevokeAllSessionsRequestProto converts proto.RevokeAllSessionsRequest to DTO
+func FromRevokeAllSessionsRequestProto(req *proto.RevokeAllSessionsRequest) RevokeAllSessionsRequestDTO {
+	return RevokeAllSessionsRequestDTO{
+		UserID:      req.UserId,
+		KeepCurrent: req.KeepCurrent,
+	}
+}
+
+// ToProtoSession converts domain.Session to proto.Session, the refresh token hash is never exposed
+func ToProtoSession(session domain.Session, currentSessionID string) *proto.Session {
+	return &proto.Session{
+		SessionId:  session.ID,
+		UserAgent:  session.UserAgent,
+		Ip:         session.IP,
+		CreatedAt:  session.CreatedAt.Unix(),
+		LastSeenAt: session.LastSeenAt.Unix(),
+		ExpiresAt:  session.ExpiresAt.Unix(),
+		Current:    currentSessionID != "" && session.ID == currentSessionID,
+	}
+}
+
+// ToProtoListSessionsResponse converts domain sessions to proto.ListSessionsResponse
+func ToProtoListSessionsResponse(sessions []domain.Session, currentSessionID string) *proto.ListSessionsResponse {
+	protoSessions := make([]*proto.Session, 0, len(sessions))
+	for _, session := range sessions {
+		protoSessions = append(protoSessions, ToProtoSession(session, currentSessionID))
+	}
+	return &proto.ListSessionsResponse{Sessions: protoSessions}
+}
@@ -0,0 +1,97 @@
+package dto
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const tokenTypeBearer = "Bearer"
+
+// RefreshTokenRequestDTO maps to proto.RefreshTokenRequest
+type RefreshTokenRequestDTO struct {
+	RefreshToken string
+}
+
+// ValidateRefreshTokenRequest ensures required fields are present
+func (dto *RefreshTokenRequestDTO) ValidateRefreshTokenRequest() error {
+	if dto.RefreshToken == "" {
+		return status.Error(codes.InvalidArgument, "refresh_token is required")
+	}
+	return nil
+}
+
+// FromRefreshTokenRequestProto converts proto.RefreshTokenRequest to DTO
+func FromRefreshTokenRequestProto(req *proto.RefreshTokenRequest) RefreshTokenRequestDTO {
+	return RefreshTokenRequestDTO{
+		RefreshToken: req.RefreshToken,
+	}
+}
+
+// TokenResponseDTO maps to proto.TokenResponse
+type TokenResponseDTO struct {
+	UserID           uint64
+	AccessToken      string
+	RefreshToken     string
+	ExpiresInSeconds int64
+
+	TwoFactorRequired bool
+	TwoFactorToken    string
+}
+
+// ToProtoTokenResponse converts DTO to proto.TokenResponse
+func (dto *TokenResponseDTO) ToProtoTokenResponse() *proto.TokenResponse {
+	if dto.TwoFactorRequired {
+		return &proto.TokenResponse{
+			UserId:            dto.UserID,
+			TwoFactorRequired: true,
+			TwoFactorToken:    dto.TwoFactorToken,
+		}
+	}
+
+	return &proto.TokenResponse{
+		UserId:       dto.UserID,
+		AccessToken:  dto.AccessToken,
+		RefreshToken: dto.RefreshToken,
+		TokenType:    tokenTypeBearer,
+		ExpiresIn:    dto.ExpiresInSeconds,
+	}
+}
+
+// FromTokenPairDomain converts domain.TokenPair to DTO
+func FromTokenPairDomain(pair domain.TokenPair) TokenResponseDTO {
+	return TokenResponseDTO{
+		UserID:            pair.UserID,
+		AccessToken:       pair.AccessToken,
+		RefreshToken:      pair.RefreshToken,
+		ExpiresInSeconds:  int64(pair.ExpiresIn.Seconds()),
+		TwoFactorRequired: pair.TwoFactorRequired,
+		TwoFactorToken:    pair.TwoFactorToken,
+	}
+}
+
+// PublicKeyResponseDTO maps to proto.PublicKeyResponse
+type PublicKeyResponseDTO struct {
+	KeyID     string
+	Algorithm string
+	PublicKey string
+}
+
+// ToProtoPublicKeyResponse converts DTO to proto.PublicKeyResponse
+func (dto *PublicKeyResponseDTO) ToProtoPublicKeyResponse() *proto.PublicKeyResponse {
+	return &proto.PublicKeyResponse{
+		KeyId:     dto.KeyID,
+		Algorithm: dto.Algorithm,
+		PublicKey: dto.PublicKey,
+	}
+}
+
+// FromPublicKeyDomain converts domain.PublicKey to DTO
+func FromPublicKeyDomain(key domain.PublicKey) PublicKeyResponseDTO {
+	return PublicKeyResponseDTO{
+		KeyID:     key.KeyID,
+		Algorithm: key.Algorithm,
+		PublicKey: key.PEM,
+	}
+}
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EnrollTwoFactorResponseDTO maps to proto.EnrollTwoFactorResponse
+type EnrollTwoFactorResponseDTO struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// ToProtoEnrollTwoFactorResponse converts DTO to proto.EnrollTwoFactorResponse
+func (dto *EnrollTwoFactorResponseDTO) ToProtoEnrollTwoFactorResponse() *proto.EnrollTwoFactorResponse {
+	return &proto.EnrollTwoFactorResponse{
+		Secret:          dto.Secret,
+		ProvisioningUri: dto.ProvisioningURI,
+	}
+}
+
+// FromTwoFactorEnrollmentDomain converts domain.TwoFactorEnrollment to DTO
+func FromTwoFactorEnrollmentDomain(enrollment domain.TwoFactorEnrollment) EnrollTwoFactorResponseDTO {
+	return EnrollTwoFactorResponseDTO{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	}
+}
+
+// TwoFactorCodeRequestDTO maps to proto.TwoFactorCodeRequest
+type TwoFactorCodeRequestDTO struct {
+	UserID uint64
+	Code   string
+}
+
+// ValidateTwoFactorCodeRequest ensures required fields are present
+func (dto *TwoFactorCodeRequestDTO) ValidateTwoFactorCodeRequest() error {
+	if dto.UserID == 0 {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if dto.Code == "" {
+		return status.Error(codes.InvalidArgument, "code is required")
+	}
+	return nil
+}
+
+// FromTwoFactorCodeRequestProto converts proto.TwoFactorCodeRequest to DTO
+func FromTwoFactorCodeRequestProto(req *proto.TwoFactorCodeRequest) TwoFactorCodeRequestDTO {
+	return TwoFactorCodeRequestDTO{
+		UserID: req.UserId,
+		Code:   req.Code,
+	}
+}
+
+// VerifyTwoFactorLoginRequestDTO maps to proto.VerifyTwoFactorLoginRequest
+type VerifyTwoFactorLoginRequestDTO struct {
+	TwoFactorToken string
+	Code           string
+}
+
+// ValidateVerifyTwoFactorLoginRequest ensures required fields are present
+func (dto *VerifyTwoFactorLoginRequestDTO) ValidateVerifyTwoFactorLoginRequest() error {
+	if dto.TwoFactorToken == "" {
+		return status.Error(codes.InvalidArgument, "two_factor_token is required")
+	}
+	if dto.Code == "" {
+		return status.Error(codes.InvalidArgument, "code is required")
+	}
+	return nil
+}
+
+// FromVerifyTwoFactorLoginRequestProto converts proto.VerifyTwoFactorLoginRequest to DTO
+func FromVerifyTwoFactorLoginRequestProto(req *proto.VerifyTwoFactorLoginRequest) VerifyTwoFactorLoginRequestDTO {
+	return VerifyTwoFactorLoginRequestDTO{
+		TwoFactorToken: req.TwoFactorToken,
+		Code:           req.Code,
+	}
+}
@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateUserProfileRequestDTO maps to proto.UpdateUserProfileRequest
+type UpdateUserProfileRequestDTO struct {
+	UserID uint64
+	Name   *string
+	Email  *string
+}
+
+// ValidateUpdateUserProfileRequest ensures at least one non-empty field is being changed
+func (dto *UpdateUserProfileRequestDTO) ValidateUpdateUserProfileRequest() error {
+	if dto.UserID == 0 {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if dto.Name == nil && dto.Email == nil {
+		return status.Error(codes.InvalidArgument, "name or email is required")
+	}
+	if dto.Name != nil && *dto.Name == "" {
+		return status.Error(codes.InvalidArgument, "name cannot be empty")
+	}
+	if dto.Email != nil && *dto.Email == "" {
+		return status.Error(codes.InvalidArgument, "email cannot be empty")
+	}
+	return nil
+}
+
+// ToDomainUserUpdate converts DTO to domain.UserUpdate
+func (dto *UpdateUserProfileRequestDTO) ToDomainUserUpdate() domain.UserUpdate {
+	return domain.UserUpdate{
+		Name:  dto.Name,
+		Email: dto.Email,
+	}
+}
+
+// FromUpdateUserProfileRequestProto converts proto.UpdateUserProfileRequest to DTO
+func FromUpdateUserProfileRequestProto(req *proto.UpdateUserProfileRequest) UpdateUserProfileRequestDTO {
+	return UpdateUserProfileRequestDTO{
+		UserID: req.UserId,
+		Name:   req.Name,
+		Email:  req.Email,
+	}
+}
+
+// ChangePasswordRequestDTO maps to proto.ChangePasswordRequest
+type ChangePasswordRequestDTO struct {
+	UserID          uint64
+	CurrentPassword string
+	NewPassword     string
+}
+
+// ValidateChangePasswordRequest ensures required fields are present
+func (dto *ChangePasswordRequestDTO) ValidateChangePasswordRequest() error {
+	if dto.UserID == 0 {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if dto.CurrentPassword == "" {
+		return status.Error(codes.InvalidArgument, "current_password is required")
+	}
+	if dto.NewPassword == "" {
+		return status.Error(codes.InvalidArgument, "new_password is required")
+	}
+	return nil
+}
+
+// FromChangePasswordRequestProto converts proto.ChangePasswordRequest to DTO
+func FromChangePasswordRequestProto(req *proto.ChangePasswordRequest) ChangePasswordRequestDTO {
+	return ChangePasswordRequestDTO{
+		UserID:          req.UserId,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	}
+}
@@ -5,6 +5,7 @@ import (
 	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"time"
 )
 
 // GetRequestDTO maps to proto.GetUserProfileRequest
@@ -35,24 +36,36 @@ func FromGetRequestProto(req *proto.UserID) GetRequestDTO {
 
 // GetResponseDTO maps to proto.GetUserProfileResponse
 type GetResponseDTO struct {
-	UserID uint64
-	Email  string
-	Name   string
+	UserID           uint64
+	Email            string
+	Name             string
+	Role             string
+	EmailVerified    bool
+	TwoFactorEnabled bool
+	CreatedAt        time.Time
 }
 
 func (dto *GetResponseDTO) ToProtoUserProfile() *proto.UserProfile {
 	return &proto.UserProfile{
-		UserId: dto.UserID,
-		Email:  dto.Email,
-		Name:   dto.Name,
+		UserId:           dto.UserID,
+		Email:            dto.Email,
+		Name:             dto.Name,
+		Role:             dto.Role,
+		EmailVerified:    dto.EmailVerified,
+		TwoFactorEnabled: dto.TwoFactorEnabled,
+		CreatedAt:        dto.CreatedAt.Unix(),
 	}
 }
 
 // FromUserProfileDomain converts domain.User to DTO
 func FromUserProfileDomain(user domain.User) GetResponseDTO {
 	return GetResponseDTO{
-		UserID: user.ID,
-		Email:  user.Email,
-		Name:   user.Name,
+		UserID:           user.ID,
+		Email:            user.Email,
+		Name:             user.Name,
+		Role:             string(user.Role),
+		EmailVerified:    user.EmailVerified,
+		TwoFactorEnabled: user.TwoFactor.Enabled,
+		CreatedAt:        user.CreatedAt,
 	}
 }
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateUserRoleRequestDTO maps to proto.UpdateUserRoleRequest
+type UpdateUserRoleRequestDTO struct {
+	UserID uint64
+	Role   string
+}
+
+// ValidateUpdateUserRoleRequest ensures required fields are present and the role is known
+func (dto *UpdateUserRoleRequestDTO) ValidateUpdateUserRoleRequest() error {
+	if dto.UserID == 0 {
+		return status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if !domain.Role(dto.Role).Valid() {
+		return status.Error(codes.InvalidArgument, "role must be one of: customer, sales_staff, warehouse, admin")
+	}
+	return nil
+}
+
+// ToDomainRole converts DTO role to domain.Role
+func (dto *UpdateUserRoleRequestDTO) ToDomainRole() domain.Role {
+	return domain.Role(dto.Role)
+}
+
+// FromUpdateUserRoleRequestProto converts proto.UpdateUserRoleRequest to DTO
+func FromUpdateUserRoleRequestProto(req *proto.UpdateUserRoleRequest) UpdateUserRoleRequestDTO {
+	return UpdateUserRoleRequestDTO{
+		UserID: req.UserId,
+		Role:   req.Role,
+	}
+}
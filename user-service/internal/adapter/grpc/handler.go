@@ -12,11 +12,24 @@ import (
 
 type UserGRPCServer struct {
 	proto.UnimplementedAuthServer
-	userUsecase usecase.UserUsecase
+	userUsecase    usecase.UserUsecase
+	apiKeyUsecase  usecase.APIKeyUsecase
+	addressUsecase usecase.AddressUsecase
+	privacyUsecase usecase.PrivacyUsecase
 }
 
-func NewUserGRPCServer(userUsecase usecase.UserUsecase) *UserGRPCServer {
-	return &UserGRPCServer{userUsecase: userUsecase}
+func NewUserGRPCServer(
+	userUsecase usecase.UserUsecase,
+	apiKeyUsecase usecase.APIKeyUsecase,
+	addressUsecase usecase.AddressUsecase,
+	privacyUsecase usecase.PrivacyUsecase,
+) *UserGRPCServer {
+	return &UserGRPCServer{
+		userUsecase:    userUsecase,
+		apiKeyUsecase:  apiKeyUsecase,
+		addressUsecase: addressUsecase,
+		privacyUsecase: privacyUsecase,
+	}
 }
 
 func (s *UserGRPCServer) RegisterUser(ctx context.Context, req *proto.UserRequest) (*proto.UserResponse, error) {
@@ -29,6 +42,9 @@ func (s *UserGRPCServer) RegisterUser(ctx context.Context, req *proto.UserReques
 
 	user, err := s.userUsecase.Register(ctx, requestDTO.ToDomainUserRequest())
 	if err != nil {
+		if policyErr := dto.PasswordPolicyStatus(err, "password"); policyErr != nil {
+			return nil, policyErr
+		}
 		switch err {
 		case domain.ErrUserExists:
 			return nil, status.Error(codes.AlreadyExists, "user already exists")
@@ -52,13 +68,17 @@ func (s *UserGRPCServer) AuthenticateUser(ctx context.Context, req *proto.AuthRe
 	}
 
 	// Call usecase
-	user, err := s.userUsecase.Authenticate(ctx, requestDTO.ToDomainAuthRequest())
+	user, err := s.userUsecase.Authenticate(ctx, requestDTO.ToDomainAuthRequest(), clientIP(ctx), requestDTO.TwoFactorCode)
 	if err != nil {
 		switch err {
-		case domain.ErrUserNotFound:
-			return nil, status.Error(codes.NotFound, "user not found")
-		case domain.ErrInvalidPassword:
-			return nil, status.Error(codes.Unauthenticated, "invalid password")
+		case domain.ErrInvalidCredentials:
+			return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+		case domain.ErrAccountLocked:
+			return nil, status.Error(codes.ResourceExhausted, "too many failed login attempts, try again later")
+		case domain.ErrTwoFactorRequired:
+			return nil, status.Error(codes.Unauthenticated, "two-factor code is required")
+		case domain.ErrInvalidTwoFactorCode:
+			return nil, status.Error(codes.Unauthenticated, "invalid two-factor code")
 		default:
 			return nil, status.Error(codes.Internal, err.Error())
 		}
@@ -81,13 +101,772 @@ func (s *UserGRPCServer) GetUserProfile(ctx context.Context, req *proto.UserID)
 	// Call usecase
 	user, err := s.userUsecase.Get(ctx, requestDTO.ToDomainFilterUserID())
 	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	// Convert domain to DTO and protobuf
+	responseDTO := dto.FromUserProfileDomain(user)
+	return responseDTO.ToProtoUserProfile(), nil
+}
+
+func (s *UserGRPCServer) Login(ctx context.Context, req *proto.AuthRequest) (*proto.TokenResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromAuthenticateUserRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateAuthRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	tokens, err := s.userUsecase.Login(ctx, requestDTO.ToDomainAuthRequest(), clientInfo(ctx), requestDTO.TwoFactorCode)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidCredentials:
+			return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+		case domain.ErrAccountLocked:
+			return nil, status.Error(codes.ResourceExhausted, "too many failed login attempts, try again later")
+		case domain.ErrTwoFactorRequired:
+			return nil, status.Error(codes.Unauthenticated, "two-factor code is required")
+		case domain.ErrInvalidTwoFactorCode:
+			return nil, status.Error(codes.Unauthenticated, "invalid two-factor code")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	// Convert domain to DTO and protobuf
+	responseDTO := dto.FromTokenPairDomain(tokens)
+	return responseDTO.ToProtoTokenResponse(), nil
+}
+
+func (s *UserGRPCServer) RefreshToken(ctx context.Context, req *proto.RefreshTokenRequest) (*proto.TokenResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromRefreshTokenRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateRefreshTokenRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	tokens, err := s.userUsecase.RefreshToken(ctx, requestDTO.RefreshToken, clientInfo(ctx))
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidToken:
+			return nil, status.Error(codes.Unauthenticated, "invalid refresh token")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	// Convert domain to DTO and protobuf
+	responseDTO := dto.FromTokenPairDomain(tokens)
+	return responseDTO.ToProtoTokenResponse(), nil
+}
+
+func (s *UserGRPCServer) GetPublicKey(ctx context.Context, req *proto.PublicKeyRequest) (*proto.PublicKeyResponse, error) {
+	responseDTO := dto.FromPublicKeyDomain(s.userUsecase.PublicKey())
+	return responseDTO.ToProtoPublicKeyResponse(), nil
+}
+
+func (s *UserGRPCServer) UpdateUserRole(ctx context.Context, req *proto.UpdateUserRoleRequest) (*proto.UserProfile, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromUpdateUserRoleRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateUpdateUserRoleRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	user, err := s.userUsecase.UpdateRole(ctx, requestDTO.UserID, requestDTO.ToDomainRole())
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		case domain.ErrInvalidRole:
+			return nil, status.Error(codes.InvalidArgument, "invalid role")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	// Convert domain to DTO and protobuf
+	responseDTO := dto.FromUserProfileDomain(user)
+	return responseDTO.ToProtoUserProfile(), nil
+}
+
+func (s *UserGRPCServer) UpdateUserProfile(ctx context.Context, req *proto.UpdateUserProfileRequest) (*proto.UserProfile, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromUpdateUserProfileRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateUpdateUserProfileRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	user, err := s.userUsecase.UpdateProfile(ctx, requestDTO.UserID, requestDTO.ToDomainUserUpdate())
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		case domain.ErrUserExists:
+			return nil, status.Error(codes.AlreadyExists, "email is already in use")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	// Convert domain to DTO and protobuf
+	responseDTO := dto.FromUserProfileDomain(user)
+	return responseDTO.ToProtoUserProfile(), nil
+}
+
+func (s *UserGRPCServer) ChangePassword(ctx context.Context, req *proto.ChangePasswordRequest) (*proto.ChangePasswordResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromChangePasswordRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateChangePasswordRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	err := s.userUsecase.ChangePassword(ctx, requestDTO.UserID, requestDTO.CurrentPassword, requestDTO.NewPassword, callerSessionID(ctx))
+	if err != nil {
+		if policyErr := dto.PasswordPolicyStatus(err, "new_password"); policyErr != nil {
+			return nil, policyErr
+		}
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		case domain.ErrInvalidPassword:
+			return nil, status.Error(codes.Unauthenticated, "current password is incorrect")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.ChangePasswordResponse{Message: "Password changed successfully"}, nil
+}
+
+func (s *UserGRPCServer) DeleteUser(ctx context.Context, req *proto.UserID) (*proto.DeleteUserResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromGetRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateUserID(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	err := s.userUsecase.Delete(ctx, requestDTO.UserID)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.DeleteUserResponse{Message: "User deleted successfully"}, nil
+}
+
+func (s *UserGRPCServer) RequestPasswordReset(ctx context.Context, req *proto.RequestPasswordResetRequest) (*proto.PasswordResetResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromRequestPasswordResetRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateRequestPasswordResetRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	if err := s.userUsecase.RequestPasswordReset(ctx, requestDTO.Email); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	if user.ID == 0 {
-		return nil, status.Error(codes.NotFound, "user not found")
+
+	return &proto.PasswordResetResponse{Message: "If the account exists, a password reset token has been sent"}, nil
+}
+
+func (s *UserGRPCServer) ResetPassword(ctx context.Context, req *proto.ResetPasswordRequest) (*proto.PasswordResetResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromResetPasswordRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateResetPasswordRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	err := s.userUsecase.ResetPassword(ctx, requestDTO.Token, requestDTO.NewPassword)
+	if err != nil {
+		if policyErr := dto.PasswordPolicyStatus(err, "new_password"); policyErr != nil {
+			return nil, policyErr
+		}
+		switch err {
+		case domain.ErrInvalidToken:
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired reset token")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.PasswordResetResponse{Message: "Password has been reset successfully"}, nil
+}
+
+func (s *UserGRPCServer) VerifyEmail(ctx context.Context, req *proto.VerifyEmailRequest) (*proto.UserProfile, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromVerifyEmailRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateVerifyEmailRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	user, err := s.userUsecase.VerifyEmail(ctx, requestDTO.Token)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidToken:
+			return nil, status.Error(codes.InvalidArgument, "invalid or expired verification token")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 	}
 
 	// Convert domain to DTO and protobuf
 	responseDTO := dto.FromUserProfileDomain(user)
 	return responseDTO.ToProtoUserProfile(), nil
 }
+
+func (s *UserGRPCServer) ResendVerificationEmail(ctx context.Context, req *proto.UserID) (*proto.ResendVerificationEmailResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromGetRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateUserID(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	err := s.userUsecase.ResendVerificationEmail(ctx, requestDTO.UserID)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		case domain.ErrEmailVerified:
+			return nil, status.Error(codes.FailedPrecondition, "email is already verified")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.ResendVerificationEmailResponse{Message: "Verification email has been sent"}, nil
+}
+
+func (s *UserGRPCServer) UnlockAccount(ctx context.Context, req *proto.UserID) (*proto.UnlockAccountResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromGetRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateUserID(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	err := s.userUsecase.UnlockAccount(ctx, requestDTO.UserID)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.UnlockAccountResponse{Message: "Account unlocked successfully"}, nil
+}
+
+func (s *UserGRPCServer) ListUsers(ctx context.Context, req *proto.ListUsersRequest) (*proto.ListUsersResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromListUsersRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateListUsersRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	users, total, err := s.userUsecase.List(ctx, requestDTO.ToDomainFilter(), requestDTO.ToDomainSort(), requestDTO.Page, requestDTO.Limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return dto.ToProtoListUsersResponse(users, total), nil
+}
+
+func (s *UserGRPCServer) EnrollTwoFactor(ctx context.Context, req *proto.UserID) (*proto.EnrollTwoFactorResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromGetRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateUserID(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	enrollment, err := s.userUsecase.EnrollTwoFactor(ctx, requestDTO.UserID)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		case domain.ErrTwoFactorEnabled:
+			return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is already enabled")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	// Convert domain to DTO and protobuf
+	responseDTO := dto.FromTwoFactorEnrollmentDomain(enrollment)
+	return responseDTO.ToProtoEnrollTwoFactorResponse(), nil
+}
+
+func (s *UserGRPCServer) ConfirmTwoFactor(ctx context.Context, req *proto.TwoFactorCodeRequest) (*proto.RecoveryCodesResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromTwoFactorCodeRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateTwoFactorCodeRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	recoveryCodes, err := s.userUsecase.ConfirmTwoFactor(ctx, requestDTO.UserID, requestDTO.Code)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		case domain.ErrTwoFactorEnabled:
+			return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is already enabled")
+		case domain.ErrTwoFactorNotEnabled:
+			return nil, status.Error(codes.FailedPrecondition, "two-factor enrollment has not been started")
+		case domain.ErrInvalidTwoFactorCode:
+			return nil, status.Error(codes.InvalidArgument, "invalid two-factor code")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *UserGRPCServer) DisableTwoFactor(ctx context.Context, req *proto.TwoFactorCodeRequest) (*proto.DisableTwoFactorResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromTwoFactorCodeRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateTwoFactorCodeRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	err := s.userUsecase.DisableTwoFactor(ctx, requestDTO.UserID, requestDTO.Code)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		case domain.ErrTwoFactorNotEnabled:
+			return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is not enabled")
+		case domain.ErrInvalidTwoFactorCode:
+			return nil, status.Error(codes.InvalidArgument, "invalid two-factor code")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.DisableTwoFactorResponse{Message: "Two-factor authentication disabled"}, nil
+}
+
+func (s *UserGRPCServer) VerifyTwoFactorLogin(ctx context.Context, req *proto.VerifyTwoFactorLoginRequest) (*proto.TokenResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromVerifyTwoFactorLoginRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateVerifyTwoFactorLoginRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	tokens, err := s.userUsecase.VerifyTwoFactorLogin(ctx, requestDTO.TwoFactorToken, requestDTO.Code, clientInfo(ctx))
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidToken:
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired two-factor token")
+		case domain.ErrInvalidTwoFactorCode:
+			return nil, status.Error(codes.Unauthenticated, "invalid two-factor code")
+		case domain.ErrAccountLocked:
+			return nil, status.Error(codes.ResourceExhausted, "too many failed login attempts, try again later")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	// Convert domain to DTO and protobuf
+	responseDTO := dto.FromTokenPairDomain(tokens)
+	return responseDTO.ToProtoTokenResponse(), nil
+}
+
+func (s *UserGRPCServer) CreateAPIKey(ctx context.Context, req *proto.CreateAPIKeyRequest) (*proto.CreateAPIKeyResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromCreateAPIKeyRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateCreateAPIKeyRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	rawKey, key, err := s.apiKeyUsecase.Create(ctx, requestDTO.ToDomainAPIKey())
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		case domain.ErrInvalidRole:
+			return nil, status.Error(codes.InvalidArgument, "invalid role")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.CreateAPIKeyResponse{
+		ApiKey: rawKey,
+		Key:    dto.ToProtoAPIKey(key),
+	}, nil
+}
+
+func (s *UserGRPCServer) ListAPIKeys(ctx context.Context, req *proto.UserID) (*proto.ListAPIKeysResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromGetRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateUserID(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	keys, err := s.apiKeyUsecase.List(ctx, requestDTO.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return dto.ToProtoListAPIKeysResponse(keys), nil
+}
+
+func (s *UserGRPCServer) RevokeAPIKey(ctx context.Context, req *proto.RevokeAPIKeyRequest) (*proto.RevokeAPIKeyResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromRevokeAPIKeyRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateRevokeAPIKeyRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	err := s.apiKeyUsecase.Revoke(ctx, requestDTO.UserID, requestDTO.KeyID)
+	if err != nil {
+		switch err {
+		case domain.ErrAPIKeyNotFound:
+			return nil, status.Error(codes.NotFound, "api key not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.RevokeAPIKeyResponse{Message: "API key revoked successfully"}, nil
+}
+
+func (s *UserGRPCServer) ValidateAPIKey(ctx context.Context, req *proto.ValidateAPIKeyRequest) (*proto.ValidateAPIKeyResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromValidateAPIKeyRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateValidateAPIKeyRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	key, err := s.apiKeyUsecase.Validate(ctx, requestDTO.APIKey)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidAPIKey:
+			return nil, status.Error(codes.Unauthenticated, "invalid api key")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.ValidateAPIKeyResponse{
+		UserId: key.OwnerID,
+		Role:   string(key.Role),
+		KeyId:  key.ID,
+	}, nil
+}
+
+func (s *UserGRPCServer) CreateAddress(ctx context.Context, req *proto.CreateAddressRequest) (*proto.Address, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromCreateAddressRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateCreateAddressRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	address, err := s.addressUsecase.Create(ctx, requestDTO.ToDomainAddress())
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return dto.ToProtoAddress(address), nil
+}
+
+func (s *UserGRPCServer) GetAddress(ctx context.Context, req *proto.AddressRequest) (*proto.Address, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromAddressRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateAddressRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	address, err := s.addressUsecase.Get(ctx, requestDTO.UserID, requestDTO.AddressID)
+	if err != nil {
+		switch err {
+		case domain.ErrAddressNotFound:
+			return nil, status.Error(codes.NotFound, "address not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return dto.ToProtoAddress(address), nil
+}
+
+func (s *UserGRPCServer) ListAddresses(ctx context.Context, req *proto.UserID) (*proto.ListAddressesResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromGetRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateUserID(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	addresses, err := s.addressUsecase.List(ctx, requestDTO.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return dto.ToProtoListAddressesResponse(addresses), nil
+}
+
+func (s *UserGRPCServer) UpdateAddress(ctx context.Context, req *proto.UpdateAddressRequest) (*proto.Address, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromUpdateAddressRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateUpdateAddressRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	address, err := s.addressUsecase.Update(ctx, requestDTO.UserID, requestDTO.AddressID, requestDTO.Update)
+	if err != nil {
+		switch err {
+		case domain.ErrAddressNotFound:
+			return nil, status.Error(codes.NotFound, "address not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return dto.ToProtoAddress(address), nil
+}
+
+func (s *UserGRPCServer) DeleteAddress(ctx context.Context, req *proto.AddressRequest) (*proto.DeleteAddressResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromAddressRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateAddressRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	err := s.addressUsecase.Delete(ctx, requestDTO.UserID, requestDTO.AddressID)
+	if err != nil {
+		switch err {
+		case domain.ErrAddressNotFound:
+			return nil, status.Error(codes.NotFound, "address not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.DeleteAddressResponse{Message: "Address deleted successfully"}, nil
+}
+
+func (s *UserGRPCServer) ListSessions(ctx context.Context, req *proto.UserID) (*proto.ListSessionsResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromGetRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateUserID(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	sessions, err := s.userUsecase.ListSessions(ctx, requestDTO.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return dto.ToProtoListSessionsResponse(sessions, callerSessionID(ctx)), nil
+}
+
+func (s *UserGRPCServer) RevokeSession(ctx context.Context, req *proto.RevokeSessionRequest) (*proto.RevokeSessionsResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromRevokeSessionRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateRevokeSessionRequest(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	err := s.userUsecase.RevokeSession(ctx, requestDTO.UserID, requestDTO.SessionID)
+	if err != nil {
+		switch err {
+		case domain.ErrSessionNotFound:
+			return nil, status.Error(codes.NotFound, "session not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.RevokeSessionsResponse{Message: "Session revoked successfully"}, nil
+}
+
+func (s *UserGRPCServer) RevokeAllSessions(ctx context.Context, req *proto.RevokeAllSessionsRequest) (*proto.RevokeSessionsResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromRevokeAllSessionsRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateRevokeAllSessionsRequest(); err != nil {
+		return nil, err
+	}
+
+	var keepSessionID string
+	if requestDTO.KeepCurrent {
+		keepSessionID = callerSessionID(ctx)
+	}
+
+	// Call usecase
+	revoked, err := s.userUsecase.RevokeAllSessions(ctx, requestDTO.UserID, keepSessionID)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.RevokeSessionsResponse{Message: "Sessions revoked successfully", Revoked: revoked}, nil
+}
+
+func (s *UserGRPCServer) ValidateSession(ctx context.Context, req *proto.ValidateSessionRequest) (*proto.ValidateSessionResponse, error) {
+	// Validate
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	// Call usecase
+	session, err := s.userUsecase.ValidateSession(ctx, req.SessionId)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidToken:
+			return nil, status.Error(codes.Unauthenticated, "session is not active")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.ValidateSessionResponse{UserId: session.UserID}, nil
+}
+
+func (s *UserGRPCServer) ExportMyData(ctx context.Context, req *proto.UserID) (*proto.DataExport, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromGetRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateUserID(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	export, err := s.privacyUsecase.Export(ctx, requestDTO.UserID)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	response, err := dto.ToProtoDataExport(export)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return response, nil
+}
+
+func (s *UserGRPCServer) EraseUser(ctx context.Context, req *proto.UserID) (*proto.EraseUserResponse, error) {
+	// Convert protobuf to DTO
+	requestDTO := dto.FromGetRequestProto(req)
+
+	// Validate
+	if err := requestDTO.ValidateUserID(); err != nil {
+		return nil, err
+	}
+
+	// Call usecase
+	orders, err := s.privacyUsecase.Erase(ctx, requestDTO.UserID)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			return nil, status.Error(codes.NotFound, "user not found")
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &proto.EraseUserResponse{Message: "Personal data erased successfully", Orders: orders}, nil
+}
@@ -0,0 +1,140 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	proto "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"slices"
+	"strconv"
+)
+
+// Caller identity attached by the api-gateway after it has verified the access token,
+// the client IP and user agent are attached to every request
+const (
+	metadataUserID    = "x-user-id"
+	metadataUserRole  = "x-user-role"
+	metadataSessionID = "x-session-id"
+	metadataClientIP  = "x-client-ip"
+	metadataUserAgent = "x-user-agent"
+)
+
+// Calling service and its token, attached by the gRPC clients of the other services
+const (
+	metadataServiceName  = "x-service-name"
+	metadataServiceToken = "x-service-token"
+)
+
+// methodRoles lists the RPCs restricted to specific caller roles, methods not listed here are open
+var methodRoles = map[string][]domain.Role{
+	proto.Auth_UpdateUserRole_FullMethodName: {domain.RoleAdmin},
+	proto.Auth_UnlockAccount_FullMethodName:  {domain.RoleAdmin},
+	proto.Auth_ListUsers_FullMethodName:      {domain.RoleAdmin},
+}
+
+// selfServiceMethods may only target the caller's own account, admins may target any account
+var selfServiceMethods = map[string]bool{
+	proto.Auth_UpdateUserProfile_FullMethodName:       true,
+	proto.Auth_ChangePassword_FullMethodName:          true,
+	proto.Auth_DeleteUser_FullMethodName:              true,
+	proto.Auth_ResendVerificationEmail_FullMethodName: true,
+	proto.Auth_EnrollTwoFactor_FullMethodName:         true,
+	proto.Auth_ConfirmTwoFactor_FullMethodName:        true,
+	proto.Auth_DisableTwoFactor_FullMethodName:        true,
+	proto.Auth_CreateAPIKey_FullMethodName:            true,
+	proto.Auth_ListAPIKeys_FullMethodName:             true,
+	proto.Auth_RevokeAPIKey_FullMethodName:            true,
+	proto.Auth_CreateAddress_FullMethodName:           true,
+	proto.Auth_GetAddress_FullMethodName:              true,
+	proto.Auth_ListAddresses_FullMethodName:           true,
+	proto.Auth_UpdateAddress_FullMethodName:           true,
+	proto.Auth_DeleteAddress_FullMethodName:           true,
+	proto.Auth_ListSessions_FullMethodName:            true,
+	proto.Auth_RevokeSession_FullMethodName:           true,
+	proto.Auth_RevokeAllSessions_FullMethodName:       true,
+	proto.Auth_ExportMyData_FullMethodName:            true,
+	proto.Auth_EraseUser_FullMethodName:               true,
+}
+
+// userScoped is implemented by requests that target a single user account
+type userScoped interface {
+	GetUserId() uint64
+}
+
+// authenticate rejects calls that do not come from one of the configured services, the identity and role
+// metadata of a call are only trusted once the calling service has presented its token
+func authenticate(callers map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		token, ok := callers[metadataValue(ctx, metadataServiceName)]
+		presented := metadataValue(ctx, metadataServiceToken)
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(presented)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "calling service is not authenticated")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// authorize rejects calls to restricted methods made without one of the allowed roles
+func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if allowed, ok := methodRoles[info.FullMethod]; ok {
+		if !slices.Contains(allowed, callerRole(ctx)) {
+			return nil, status.Error(codes.PermissionDenied, "caller is not allowed to perform this action")
+		}
+	}
+
+	// keys acting with a role instead of their owner's role are handed out by admins only
+	if keyReq, ok := req.(*proto.CreateAPIKeyRequest); ok && keyReq.Role != "" && callerRole(ctx) != domain.RoleAdmin {
+		return nil, status.Error(codes.PermissionDenied, "only admins can create role scoped api keys")
+	}
+
+	if selfServiceMethods[info.FullMethod] && callerRole(ctx) != domain.RoleAdmin {
+		scoped, ok := req.(userScoped)
+		if !ok || scoped.GetUserId() != callerID(ctx) {
+			return nil, status.Error(codes.PermissionDenied, "caller may only manage their own account")
+		}
+	}
+
+	return handler(ctx, req)
+}
+
+func callerRole(ctx context.Context) domain.Role {
+	return domain.Role(metadataValue(ctx, metadataUserRole))
+}
+
+func callerID(ctx context.Context) uint64 {
+	id, _ := strconv.ParseUint(metadataValue(ctx, metadataUserID), 10, 64)
+	return id
+}
+
+func callerSessionID(ctx context.Context) string {
+	return metadataValue(ctx, metadataSessionID)
+}
+
+func clientIP(ctx context.Context) string {
+	return metadataValue(ctx, metadataClientIP)
+}
+
+func clientInfo(ctx context.Context) domain.ClientInfo {
+	return domain.ClientInfo{
+		IP:        clientIP(ctx),
+		UserAgent: metadataValue(ctx, metadataUserAgent),
+	}
+}
+
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
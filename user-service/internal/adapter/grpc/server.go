@@ -27,10 +27,16 @@ type ServerAPI struct {
 }
 
 // New creates a new gRPC Server instance
-func New(cfg config.Server, userUsecase usecase.UserUsecase) *ServerAPI {
-	grpcServer := grpc.NewServer()
-
-	userHandler := NewUserGRPCServer(userUsecase)
+func New(
+	cfg config.Server,
+	userUsecase usecase.UserUsecase,
+	apiKeyUsecase usecase.APIKeyUsecase,
+	addressUsecase usecase.AddressUsecase,
+	privacyUsecase usecase.PrivacyUsecase,
+) *ServerAPI {
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(authenticate(cfg.GRPCServer.Callers), authorize))
+
+	userHandler := NewUserGRPCServer(userUsecase, apiKeyUsecase, addressUsecase, privacyUsecase)
 
 	// Register the Auth service with the gRPC server
 	proto.RegisterAuthServer(grpcServer, userHandler)
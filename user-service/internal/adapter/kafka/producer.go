@@ -0,0 +1,81 @@
+package kafka
+
+import (
+	"context"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	events "github.com/BeksultanSE/Assignment1-user/protos/gen/golang"
+	"github.com/IBM/sarama"
+	"google.golang.org/protobuf/proto"
+	"strconv"
+	"time"
+)
+
+const (
+	TopicUserRegistered = "user.registered"
+	TopicUserUpdated    = "user.updated"
+	TopicUserDeleted    = "user.deleted"
+)
+
+type Producer struct {
+	producer sarama.SyncProducer
+}
+
+func NewKafkaProducer(brokers []string) (*Producer, error) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	return &Producer{producer: producer}, nil
+}
+
+func (p *Producer) PublishUserRegistered(ctx context.Context, user domain.User) error {
+	return p.publish(TopicUserRegistered, user.ID, &events.UserRegisteredEvent{
+		UserId:       user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		Role:         string(user.Role),
+		RegisteredAt: user.CreatedAt.Unix(),
+	})
+}
+
+func (p *Producer) PublishUserUpdated(ctx context.Context, user domain.User) error {
+	return p.publish(TopicUserUpdated, user.ID, &events.UserUpdatedEvent{
+		UserId:        user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Role:          string(user.Role),
+		EmailVerified: user.EmailVerified,
+		UpdatedAt:     user.UpdatedAt.Unix(),
+	})
+}
+
+func (p *Producer) PublishUserDeleted(ctx context.Context, userID uint64, erased bool) error {
+	return p.publish(TopicUserDeleted, userID, &events.UserDeletedEvent{
+		UserId:    userID,
+		Erased:    erased,
+		DeletedAt: time.Now().Unix(),
+	})
+}
+
+// publish keys the messages by user id so that the events of one user stay in order
+func (p *Producer) publish(topic string, userID uint64, event proto.Message) error {
+	eventBytes, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	msg := &sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(strconv.FormatUint(userID, 10)),
+		Value: sarama.ByteEncoder(eventBytes),
+	}
+
+	_, _, err = p.producer.SendMessage(msg)
+	return err
+}
+
+func (p *Producer) Close() error {
+	return p.producer.Close()
+}
@@ -0,0 +1,152 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/adapter/mongo/dao"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+)
+
+type AddressRepo struct {
+	conn       *mongo.Database
+	collection string
+}
+
+func NewAddressRepo(conn *mongo.Database) *AddressRepo {
+	return &AddressRepo{
+		conn:       conn,
+		collection: CollectionAddresses,
+	}
+}
+
+// EnsureIndexes speeds up listing a user's address book
+func (r *AddressRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.conn.Collection(r.collection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "userId", Value: 1}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create address indexes: %w", err)
+	}
+	return nil
+}
+
+func (r *AddressRepo) Create(ctx context.Context, address domain.Address) error {
+	_, err := r.conn.Collection(r.collection).InsertOne(ctx, dao.FromAddress(address))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *AddressRepo) GetWithFilter(ctx context.Context, filter domain.AddressFilter) (domain.Address, error) {
+	var addressDao dao.Address
+	err := r.conn.Collection(r.collection).FindOne(
+		ctx,
+		dao.FromAddressFilter(filter),
+	).Decode(&addressDao)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Address{}, domain.ErrAddressNotFound
+		}
+		return domain.Address{}, err
+	}
+	return dao.ToAddress(addressDao), nil
+}
+
+func (r *AddressRepo) GetListWithFilter(ctx context.Context, filter domain.AddressFilter) ([]domain.Address, error) {
+	cursor, err := r.conn.Collection(r.collection).Find(
+		ctx,
+		dao.FromAddressFilter(filter),
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("failed to close cursor: %v", err)
+		}
+	}(cursor, ctx)
+
+	var addressDaos []dao.Address
+	if err = cursor.All(ctx, &addressDaos); err != nil {
+		return nil, err
+	}
+
+	addresses := make([]domain.Address, 0, len(addressDaos))
+	for _, addressDao := range addressDaos {
+		addresses = append(addresses, dao.ToAddress(addressDao))
+	}
+	return addresses, nil
+}
+
+func (r *AddressRepo) Update(ctx context.Context, filter domain.AddressFilter, update domain.AddressUpdate) error {
+	res, err := r.conn.Collection(r.collection).UpdateOne(
+		ctx,
+		dao.FromAddressFilter(filter),
+		dao.FromAddressUpdate(update),
+	)
+	if err != nil {
+		return fmt.Errorf("address has not been updated with filter: %v, err: %w", filter, err)
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrAddressNotFound
+	}
+
+	return nil
+}
+
+// ClearDefaults removes the default shipping and/or billing flag from all of the user's addresses except one
+func (r *AddressRepo) ClearDefaults(ctx context.Context, userID, exceptID uint64, shipping, billing bool) error {
+	set := bson.M{}
+	if shipping {
+		set["defaultShipping"] = false
+	}
+	if billing {
+		set["defaultBilling"] = false
+	}
+	if len(set) == 0 {
+		return nil
+	}
+
+	_, err := r.conn.Collection(r.collection).UpdateMany(
+		ctx,
+		bson.M{"userId": userID, "_id": bson.M{"$ne": exceptID}},
+		bson.M{"$set": set},
+	)
+	if err != nil {
+		return fmt.Errorf("default addresses have not been cleared for user: %d, err: %w", userID, err)
+	}
+	return nil
+}
+
+func (r *AddressRepo) Delete(ctx context.Context, filter domain.AddressFilter) error {
+	res, err := r.conn.Collection(r.collection).DeleteOne(
+		ctx,
+		dao.FromAddressFilter(filter),
+	)
+	if err != nil {
+		return fmt.Errorf("address has not been deleted with filter: %v, err: %w", filter, err)
+	}
+
+	if res.DeletedCount == 0 {
+		return domain.ErrAddressNotFound
+	}
+
+	return nil
+}
+
+// DeleteByUser removes the whole address book of the user
+func (r *AddressRepo) DeleteByUser(ctx context.Context, userID uint64) error {
+	_, err := r.conn.Collection(r.collection).DeleteMany(ctx, bson.M{"userId": userID})
+	if err != nil {
+		return fmt.Errorf("addresses have not been deleted for user: %d, err: %w", userID, err)
+	}
+	return nil
+}
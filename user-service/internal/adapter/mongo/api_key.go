@@ -0,0 +1,123 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/adapter/mongo/dao"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"time"
+)
+
+type APIKeyRepo struct {
+	conn       *mongo.Database
+	collection string
+}
+
+func NewAPIKeyRepo(conn *mongo.Database) *APIKeyRepo {
+	return &APIKeyRepo{
+		conn:       conn,
+		collection: CollectionAPIKeys,
+	}
+}
+
+// EnsureIndexes makes key lookups by hash unique and fast, and speeds up listing by owner
+func (r *APIKeyRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.conn.Collection(r.collection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "keyHash", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "ownerId", Value: 1}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create api key indexes: %w", err)
+	}
+	return nil
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, key domain.APIKey) error {
+	_, err := r.conn.Collection(r.collection).InsertOne(ctx, dao.FromAPIKey(key))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *APIKeyRepo) GetWithFilter(ctx context.Context, filter domain.APIKeyFilter) (domain.APIKey, error) {
+	var keyDao dao.APIKey
+	err := r.conn.Collection(r.collection).FindOne(
+		ctx,
+		dao.FromAPIKeyFilter(filter),
+	).Decode(&keyDao)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.APIKey{}, domain.ErrAPIKeyNotFound
+		}
+		return domain.APIKey{}, err
+	}
+	return dao.ToAPIKey(keyDao), nil
+}
+
+func (r *APIKeyRepo) GetListWithFilter(ctx context.Context, filter domain.APIKeyFilter) ([]domain.APIKey, error) {
+	cursor, err := r.conn.Collection(r.collection).Find(
+		ctx,
+		dao.FromAPIKeyFilter(filter),
+		options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("failed to close cursor: %v", err)
+		}
+	}(cursor, ctx)
+
+	var keyDaos []dao.APIKey
+	if err = cursor.All(ctx, &keyDaos); err != nil {
+		return nil, err
+	}
+
+	keys := make([]domain.APIKey, 0, len(keyDaos))
+	for _, keyDao := range keyDaos {
+		keys = append(keys, dao.ToAPIKey(keyDao))
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepo) Update(ctx context.Context, filter domain.APIKeyFilter, update domain.APIKeyUpdate) error {
+	res, err := r.conn.Collection(r.collection).UpdateOne(
+		ctx,
+		dao.FromAPIKeyFilter(filter),
+		dao.FromAPIKeyUpdate(update),
+	)
+	if err != nil {
+		return fmt.Errorf("api key has not been updated with filter: %v, err: %w", filter, err)
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// RevokeAll revokes every active key of the owner
+func (r *APIKeyRepo) RevokeAll(ctx context.Context, ownerID uint64, now time.Time) error {
+	_, err := r.conn.Collection(r.collection).UpdateMany(
+		ctx,
+		bson.M{"ownerId": ownerID, "revokedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revokedAt": now}},
+	)
+	if err != nil {
+		return fmt.Errorf("api keys have not been revoked for owner: %d, err: %w", ownerID, err)
+	}
+	return nil
+}
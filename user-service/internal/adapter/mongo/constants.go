@@ -3,6 +3,12 @@ package mongo
 import "github.com/BeksultanSE/Assignment1-user/internal/domain"
 
 const (
-	CollectionAutoInc = "counters"
-	CollectionUsers   = domain.UserDB
+	CollectionAutoInc            = "counters"
+	CollectionUsers              = domain.UserDB
+	CollectionPasswordResets     = domain.PasswordResetsDB
+	CollectionEmailVerifications = domain.EmailVerificationsDB
+	CollectionLoginAttempts      = domain.LoginAttemptsDB
+	CollectionAPIKeys            = domain.APIKeysDB
+	CollectionAddresses          = domain.AddressesDB
+	CollectionSessions           = domain.SessionsDB
 )
@@ -0,0 +1,131 @@
+package dao
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+type Address struct {
+	ID              uint64    `bson:"_id"`
+	UserID          uint64    `bson:"userId"`
+	Label           string    `bson:"label"`
+	RecipientName   string    `bson:"recipientName"`
+	Phone           string    `bson:"phone"`
+	Line1           string    `bson:"line1"`
+	Line2           string    `bson:"line2"`
+	City            string    `bson:"city"`
+	Region          string    `bson:"region"`
+	PostalCode      string    `bson:"postalCode"`
+	Country         string    `bson:"country"`
+	DefaultShipping bool      `bson:"defaultShipping"`
+	DefaultBilling  bool      `bson:"defaultBilling"`
+	CreatedAt       time.Time `bson:"createdAt"`
+	UpdatedAt       time.Time `bson:"updatedAt"`
+}
+
+// FromAddress converts address model to dao for mongo
+func FromAddress(address domain.Address) Address {
+	return Address{
+		ID:              address.ID,
+		UserID:          address.UserID,
+		Label:           address.Label,
+		RecipientName:   address.RecipientName,
+		Phone:           address.Phone,
+		Line1:           address.Line1,
+		Line2:           address.Line2,
+		City:            address.City,
+		Region:          address.Region,
+		PostalCode:      address.PostalCode,
+		Country:         address.Country,
+		DefaultShipping: address.DefaultShipping,
+		DefaultBilling:  address.DefaultBilling,
+		CreatedAt:       address.CreatedAt,
+		UpdatedAt:       address.UpdatedAt,
+	}
+}
+
+// ToAddress converts dao address to model
+func ToAddress(address Address) domain.Address {
+	return domain.Address{
+		ID:              address.ID,
+		UserID:          address.UserID,
+		Label:           address.Label,
+		RecipientName:   address.RecipientName,
+		Phone:           address.Phone,
+		Line1:           address.Line1,
+		Line2:           address.Line2,
+		City:            address.City,
+		Region:          address.Region,
+		PostalCode:      address.PostalCode,
+		Country:         address.Country,
+		DefaultShipping: address.DefaultShipping,
+		DefaultBilling:  address.DefaultBilling,
+		CreatedAt:       address.CreatedAt,
+		UpdatedAt:       address.UpdatedAt,
+	}
+}
+
+// FromAddressFilter constructs filtering query for mongo
+func FromAddressFilter(filter domain.AddressFilter) bson.M {
+	query := bson.M{}
+
+	if filter.ID != nil {
+		query["_id"] = filter.ID
+	}
+	if filter.UserID != nil {
+		query["userId"] = filter.UserID
+	}
+	if filter.DefaultShipping != nil {
+		query["defaultShipping"] = filter.DefaultShipping
+	}
+	if filter.DefaultBilling != nil {
+		query["defaultBilling"] = filter.DefaultBilling
+	}
+
+	return query
+}
+
+// FromAddressUpdate constructs updating query for mongo
+func FromAddressUpdate(update domain.AddressUpdate) bson.M {
+	query := bson.M{}
+
+	if update.Label != nil {
+		query["label"] = update.Label
+	}
+	if update.RecipientName != nil {
+		query["recipientName"] = update.RecipientName
+	}
+	if update.Phone != nil {
+		query["phone"] = update.Phone
+	}
+	if update.Line1 != nil {
+		query["line1"] = update.Line1
+	}
+	if update.Line2 != nil {
+		query["line2"] = update.Line2
+	}
+	if update.City != nil {
+		query["city"] = update.City
+	}
+	if update.Region != nil {
+		query["region"] = update.Region
+	}
+	if update.PostalCode != nil {
+		query["postalCode"] = update.PostalCode
+	}
+	if update.Country != nil {
+		query["country"] = update.Country
+	}
+	if update.DefaultShipping != nil {
+		query["defaultShipping"] = update.DefaultShipping
+	}
+	if update.DefaultBilling != nil {
+		query["defaultBilling"] = update.DefaultBilling
+	}
+	if update.UpdatedAt != nil {
+		query["updatedAt"] = update.UpdatedAt
+	}
+
+	return bson.M{"$set": query}
+}
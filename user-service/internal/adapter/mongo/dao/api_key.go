@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+type APIKey struct {
+	ID         uint64    `bson:"_id"`
+	Name       string    `bson:"name"`
+	Prefix     string    `bson:"prefix"`
+	KeyHash    string    `bson:"keyHash"`
+	OwnerID    uint64    `bson:"ownerId"`
+	Role       string    `bson:"role,omitempty"`
+	CreatedAt  time.Time `bson:"createdAt"`
+	LastUsedAt time.Time `bson:"lastUsedAt,omitempty"`
+	RevokedAt  time.Time `bson:"revokedAt,omitempty"`
+}
+
+// FromAPIKey converts API key model to dao for mongo
+func FromAPIKey(key domain.APIKey) APIKey {
+	return APIKey{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		KeyHash:    key.KeyHash,
+		OwnerID:    key.OwnerID,
+		Role:       string(key.Role),
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
+
+// ToAPIKey converts dao API key to model
+func ToAPIKey(key APIKey) domain.APIKey {
+	return domain.APIKey{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		KeyHash:    key.KeyHash,
+		OwnerID:    key.OwnerID,
+		Role:       domain.Role(key.Role),
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
+
+// FromAPIKeyFilter constructs filtering query for mongo
+func FromAPIKeyFilter(filter domain.APIKeyFilter) bson.M {
+	query := bson.M{}
+
+	if filter.ID != nil {
+		query["_id"] = filter.ID
+	}
+	if filter.OwnerID != nil {
+		query["ownerId"] = filter.OwnerID
+	}
+	if filter.KeyHash != nil {
+		query["keyHash"] = filter.KeyHash
+	}
+
+	return query
+}
+
+// FromAPIKeyUpdate constructs updating query for mongo
+func FromAPIKeyUpdate(update domain.APIKeyUpdate) bson.M {
+	query := bson.M{}
+
+	if update.LastUsedAt != nil {
+		query["lastUsedAt"] = update.LastUsedAt
+	}
+	if update.RevokedAt != nil {
+		query["revokedAt"] = update.RevokedAt
+	}
+
+	return bson.M{"$set": query}
+}
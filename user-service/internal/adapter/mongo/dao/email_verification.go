@@ -0,0 +1,36 @@
+package dao
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"time"
+)
+
+type EmailVerificationToken struct {
+	TokenHash string    `bson:"_id"`
+	UserID    uint64    `bson:"userId"`
+	Email     string    `bson:"email"`
+	ExpiresAt time.Time `bson:"expiresAt"`
+	CreatedAt time.Time `bson:"createdAt"`
+}
+
+// FromEmailVerificationToken converts verification token model to dao for mongo
+func FromEmailVerificationToken(token domain.EmailVerificationToken) EmailVerificationToken {
+	return EmailVerificationToken{
+		TokenHash: token.TokenHash,
+		UserID:    token.UserID,
+		Email:     token.Email,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+// ToEmailVerificationToken converts dao verification token to model
+func ToEmailVerificationToken(token EmailVerificationToken) domain.EmailVerificationToken {
+	return domain.EmailVerificationToken{
+		TokenHash: token.TokenHash,
+		UserID:    token.UserID,
+		Email:     token.Email,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package dao
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"time"
+)
+
+type LoginAttempts struct {
+	Key           string    `bson:"_id"`
+	Failures      int       `bson:"failures"`
+	Lockouts      int       `bson:"lockouts"`
+	LockedUntil   time.Time `bson:"lockedUntil,omitempty"`
+	LastFailureAt time.Time `bson:"lastFailureAt"`
+	ExpiresAt     time.Time `bson:"expiresAt"`
+}
+
+// ToLoginAttempts converts dao login attempts to model
+func ToLoginAttempts(attempts LoginAttempts) domain.LoginAttempts {
+	return domain.LoginAttempts{
+		Key:           attempts.Key,
+		Failures:      attempts.Failures,
+		Lockouts:      attempts.Lockouts,
+		LockedUntil:   attempts.LockedUntil,
+		LastFailureAt: attempts.LastFailureAt,
+	}
+}
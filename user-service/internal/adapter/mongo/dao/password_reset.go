@@ -0,0 +1,33 @@
+package dao
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"time"
+)
+
+type PasswordResetToken struct {
+	TokenHash string    `bson:"_id"`
+	UserID    uint64    `bson:"userId"`
+	ExpiresAt time.Time `bson:"expiresAt"`
+	CreatedAt time.Time `bson:"createdAt"`
+}
+
+// FromPasswordResetToken converts reset token model to dao for mongo
+func FromPasswordResetToken(token domain.PasswordResetToken) PasswordResetToken {
+	return PasswordResetToken{
+		TokenHash: token.TokenHash,
+		UserID:    token.UserID,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+}
+
+// ToPasswordResetToken converts dao reset token to model
+func ToPasswordResetToken(token PasswordResetToken) domain.PasswordResetToken {
+	return domain.PasswordResetToken{
+		TokenHash: token.TokenHash,
+		UserID:    token.UserID,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+}
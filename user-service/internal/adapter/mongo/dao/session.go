@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+type Session struct {
+	ID               string    `bson:"_id"`
+	UserID           uint64    `bson:"userId"`
+	UserAgent        string    `bson:"userAgent"`
+	IP               string    `bson:"ip"`
+	TwoFactor        bool      `bson:"twoFactor"`
+	RefreshTokenHash string    `bson:"refreshTokenHash"`
+	CreatedAt        time.Time `bson:"createdAt"`
+	LastSeenAt       time.Time `bson:"lastSeenAt"`
+	ExpiresAt        time.Time `bson:"expiresAt"`
+	RevokedAt        time.Time `bson:"revokedAt,omitempty"`
+}
+
+// FromSession converts session model to dao for mongo
+func FromSession(session domain.Session) Session {
+	return Session{
+		ID:               session.ID,
+		UserID:           session.UserID,
+		UserAgent:        session.UserAgent,
+		IP:               session.IP,
+		TwoFactor:        session.TwoFactor,
+		RefreshTokenHash: session.RefreshTokenHash,
+		CreatedAt:        session.CreatedAt,
+		LastSeenAt:       session.LastSeenAt,
+		ExpiresAt:        session.ExpiresAt,
+		RevokedAt:        session.RevokedAt,
+	}
+}
+
+// ToSession converts dao session to model
+func ToSession(session Session) domain.Session {
+	return domain.Session{
+		ID:               session.ID,
+		UserID:           session.UserID,
+		UserAgent:        session.UserAgent,
+		IP:               session.IP,
+		TwoFactor:        session.TwoFactor,
+		RefreshTokenHash: session.RefreshTokenHash,
+		CreatedAt:        session.CreatedAt,
+		LastSeenAt:       session.LastSeenAt,
+		ExpiresAt:        session.ExpiresAt,
+		RevokedAt:        session.RevokedAt,
+	}
+}
+
+// FromSessionFilter constructs filtering query for mongo
+func FromSessionFilter(filter domain.SessionFilter) bson.M {
+	query := bson.M{}
+
+	if filter.ID != nil {
+		query["_id"] = filter.ID
+	}
+	if filter.UserID != nil {
+		query["userId"] = filter.UserID
+	}
+
+	return query
+}
+
+// FromSessionUpdate constructs updating query for mongo
+func FromSessionUpdate(update domain.SessionUpdate) bson.M {
+	query := bson.M{}
+
+	if update.LastSeenAt != nil {
+		query["lastSeenAt"] = update.LastSeenAt
+	}
+	if update.RevokedAt != nil {
+		query["revokedAt"] = update.RevokedAt
+	}
+
+	return bson.M{"$set": query}
+}
@@ -3,6 +3,8 @@ package dao
 import (
 	"github.com/BeksultanSE/Assignment1-user/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"regexp"
 	"time"
 )
 
@@ -11,8 +13,20 @@ type User struct {
 	Name           string    `bson:"name"`
 	Email          string    `bson:"email"`
 	HashedPassword string    `bson:"hashed_password"`
+	Role           string    `bson:"role"`
+	EmailVerified  *bool     `bson:"emailVerified,omitempty"`
+	TwoFactor      TwoFactor `bson:"twoFactor"`
 	CreatedAt      time.Time `bson:"createdAt"`
 	UpdatedAt      time.Time `bson:"updatedAt"`
+	ErasedAt       time.Time `bson:"erasedAt,omitempty"`
+}
+
+type TwoFactor struct {
+	Enabled       bool     `bson:"enabled"`
+	Secret        string   `bson:"secret,omitempty"`
+	PendingSecret string   `bson:"pendingSecret,omitempty"`
+	RecoveryCodes []string `bson:"recoveryCodes,omitempty"`
+	LastUsedStep  int64    `bson:"lastUsedStep"`
 }
 
 // FromUser converts user model to user dao for mongo
@@ -22,21 +36,46 @@ func FromUser(user domain.User) User {
 		Name:           user.Name,
 		Email:          user.Email,
 		HashedPassword: user.HashedPassword,
+		Role:           string(user.Role),
+		EmailVerified:  &user.EmailVerified,
+		TwoFactor:      fromTwoFactor(user.TwoFactor),
 		CreatedAt:      user.CreatedAt,
 		UpdatedAt:      user.UpdatedAt,
+		ErasedAt:       user.ErasedAt,
 	}
 }
 
 // ToUser converts dao user to user model
 func ToUser(user User) domain.User {
+	role := domain.Role(user.Role)
+	if role == "" {
+		// users registered before roles were introduced
+		role = domain.RoleCustomer
+	}
+	// users registered before email verification have no flag and count as verified
+	emailVerified := user.EmailVerified == nil || *user.EmailVerified
+
 	return domain.User{
 		ID:             user.ID,
 		Name:           user.Name,
 		Email:          user.Email,
 		HashedPassword: user.HashedPassword,
+		Role:           role,
+		EmailVerified:  emailVerified,
+		TwoFactor:      toTwoFactor(user.TwoFactor),
 		CreatedAt:      user.CreatedAt,
 		UpdatedAt:      user.UpdatedAt,
+		ErasedAt:       user.ErasedAt,
+	}
+}
+
+// ToUserList converts a list of dao users to user models
+func ToUserList(users []User) []domain.User {
+	result := make([]domain.User, 0, len(users))
+	for _, user := range users {
+		result = append(result, ToUser(user))
 	}
+	return result
 }
 
 // FromUserFilter constructs filtering query for mongo
@@ -52,10 +91,58 @@ func FromUserFilter(filter domain.UserFilter) bson.M {
 	if filter.Email != nil {
 		query["email"] = filter.Email
 	}
+	if filter.Search != nil && *filter.Search != "" {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(*filter.Search), Options: "i"}
+		query["$or"] = bson.A{
+			bson.M{"name": pattern},
+			bson.M{"email": pattern},
+		}
+	}
+	if filter.Role != nil {
+		if *filter.Role == domain.RoleCustomer {
+			// users registered before roles were introduced have no role stored
+			query["role"] = bson.M{"$in": bson.A{string(domain.RoleCustomer), "", nil}}
+		} else {
+			query["role"] = string(*filter.Role)
+		}
+	}
+	if filter.CreatedFrom != nil || filter.CreatedTo != nil {
+		createdAt := bson.M{}
+		if filter.CreatedFrom != nil {
+			createdAt["$gte"] = filter.CreatedFrom
+		}
+		if filter.CreatedTo != nil {
+			createdAt["$lte"] = filter.CreatedTo
+		}
+		query["createdAt"] = createdAt
+	}
 
 	return query
 }
 
+// FromUserSort constructs sorting options for mongo, ties are broken by id
+func FromUserSort(sort domain.UserSort) bson.D {
+	direction := 1
+	if sort.Descending {
+		direction = -1
+	}
+
+	field := "_id"
+	switch sort.Field {
+	case domain.UserSortByName:
+		field = "name"
+	case domain.UserSortByEmail:
+		field = "email"
+	case domain.UserSortByCreatedAt:
+		field = "createdAt"
+	}
+
+	if field == "_id" {
+		return bson.D{{Key: "_id", Value: direction}}
+	}
+	return bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}
+}
+
 // FromUserUpdate constructs updating query for mongo
 func FromUserUpdate(update domain.UserUpdate) bson.M {
 	query := bson.M{}
@@ -69,6 +156,41 @@ func FromUserUpdate(update domain.UserUpdate) bson.M {
 	if update.HashedPassword != nil {
 		query["hashed_password"] = update.HashedPassword
 	}
+	if update.Role != nil {
+		query["role"] = string(*update.Role)
+	}
+	if update.EmailVerified != nil {
+		query["emailVerified"] = update.EmailVerified
+	}
+	if update.TwoFactor != nil {
+		query["twoFactor"] = fromTwoFactor(*update.TwoFactor)
+	}
+	if update.UpdatedAt != nil {
+		query["updatedAt"] = update.UpdatedAt
+	}
+	if update.ErasedAt != nil {
+		query["erasedAt"] = update.ErasedAt
+	}
 
 	return bson.M{"$set": query}
 }
+
+func fromTwoFactor(tf domain.TwoFactor) TwoFactor {
+	return TwoFactor{
+		Enabled:       tf.Enabled,
+		Secret:        tf.Secret,
+		PendingSecret: tf.PendingSecret,
+		RecoveryCodes: tf.RecoveryCodes,
+		LastUsedStep:  tf.LastUsedStep,
+	}
+}
+
+func toTwoFactor(tf TwoFactor) domain.TwoFactor {
+	return domain.TwoFactor{
+		Enabled:       tf.Enabled,
+		Secret:        tf.Secret,
+		PendingSecret: tf.PendingSecret,
+		RecoveryCodes: tf.RecoveryCodes,
+		LastUsedStep:  tf.LastUsedStep,
+	}
+}
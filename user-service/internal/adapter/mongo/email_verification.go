@@ -0,0 +1,74 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/adapter/mongo/dao"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+type EmailVerificationRepo struct {
+	conn       *mongo.Database
+	collection string
+}
+
+func NewEmailVerificationRepo(conn *mongo.Database) *EmailVerificationRepo {
+	return &EmailVerificationRepo{
+		conn:       conn,
+		collection: CollectionEmailVerifications,
+	}
+}
+
+// EnsureIndexes lets mongo drop expired tokens on its own and speeds up lookups by user
+func (r *EmailVerificationRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.conn.Collection(r.collection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+		{
+			Keys: bson.D{{Key: "userId", Value: 1}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create email verification indexes: %w", err)
+	}
+	return nil
+}
+
+func (r *EmailVerificationRepo) Create(ctx context.Context, token domain.EmailVerificationToken) error {
+	_, err := r.conn.Collection(r.collection).InsertOne(ctx, dao.FromEmailVerificationToken(token))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Consume atomically removes an unexpired token so it can never be used twice
+func (r *EmailVerificationRepo) Consume(ctx context.Context, tokenHash string, now time.Time) (domain.EmailVerificationToken, error) {
+	var tokenDao dao.EmailVerificationToken
+	err := r.conn.Collection(r.collection).FindOneAndDelete(
+		ctx,
+		bson.M{"_id": tokenHash, "expiresAt": bson.M{"$gt": now}},
+	).Decode(&tokenDao)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.EmailVerificationToken{}, domain.ErrInvalidToken
+		}
+		return domain.EmailVerificationToken{}, err
+	}
+	return dao.ToEmailVerificationToken(tokenDao), nil
+}
+
+func (r *EmailVerificationRepo) DeleteByUser(ctx context.Context, userID uint64) error {
+	_, err := r.conn.Collection(r.collection).DeleteMany(ctx, bson.M{"userId": userID})
+	if err != nil {
+		return fmt.Errorf("email verification tokens have not been deleted for user: %d, err: %w", userID, err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/adapter/mongo/dao"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+type LoginAttemptRepo struct {
+	conn       *mongo.Database
+	collection string
+}
+
+func NewLoginAttemptRepo(conn *mongo.Database) *LoginAttemptRepo {
+	return &LoginAttemptRepo{
+		conn:       conn,
+		collection: CollectionLoginAttempts,
+	}
+}
+
+// EnsureIndexes lets mongo drop attempt records once their history is no longer relevant
+func (r *LoginAttemptRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.conn.Collection(r.collection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create login attempt indexes: %w", err)
+	}
+	return nil
+}
+
+// Get returns the attempts recorded for the key, or empty attempts if there are none
+func (r *LoginAttemptRepo) Get(ctx context.Context, key string) (domain.LoginAttempts, error) {
+	var attemptsDao dao.LoginAttempts
+	err := r.conn.Collection(r.collection).FindOne(ctx, bson.M{"_id": key}).Decode(&attemptsDao)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.LoginAttempts{Key: key}, nil
+		}
+		return domain.LoginAttempts{}, err
+	}
+	return dao.ToLoginAttempts(attemptsDao), nil
+}
+
+// RecordFailure atomically counts a failed login. Failures older than window and lockouts
+// older than resetAfter are forgotten in the same update.
+func (r *LoginAttemptRepo) RecordFailure(ctx context.Context, key string, now time.Time, window, resetAfter time.Duration) (domain.LoginAttempts, error) {
+	windowStart := now.Add(-window)
+	resetStart := now.Add(-resetAfter)
+
+	// a pipeline update so that the counters are reset based on the previous failure time
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"failures": bson.M{"$add": bson.A{
+				bson.M{"$cond": bson.A{
+					bson.M{"$lt": bson.A{"$lastFailureAt", windowStart}}, 0, bson.M{"$ifNull": bson.A{"$failures", 0}},
+				}},
+				1,
+			}},
+			"lockouts": bson.M{"$cond": bson.A{
+				bson.M{"$lt": bson.A{"$lastFailureAt", resetStart}}, 0, bson.M{"$ifNull": bson.A{"$lockouts", 0}},
+			}},
+			"lastFailureAt": now,
+			"expiresAt":     now.Add(resetAfter),
+		}}},
+	}
+
+	var attemptsDao dao.LoginAttempts
+	err := r.conn.Collection(r.collection).FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": key},
+		update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&attemptsDao)
+	if err != nil {
+		return domain.LoginAttempts{}, fmt.Errorf("login failure has not been recorded for key: %s, err: %w", key, err)
+	}
+	return dao.ToLoginAttempts(attemptsDao), nil
+}
+
+// Lock refuses logins for the key until the given time and starts counting failures anew
+func (r *LoginAttemptRepo) Lock(ctx context.Context, key string, until time.Time, resetAfter time.Duration) error {
+	_, err := r.conn.Collection(r.collection).UpdateOne(
+		ctx,
+		bson.M{"_id": key},
+		bson.M{
+			"$set": bson.M{"failures": 0, "lockedUntil": until, "expiresAt": until.Add(resetAfter)},
+			"$inc": bson.M{"lockouts": 1},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("key has not been locked: %s, err: %w", key, err)
+	}
+	return nil
+}
+
+// Reset forgets all failures and lockouts recorded for the key
+func (r *LoginAttemptRepo) Reset(ctx context.Context, key string) error {
+	_, err := r.conn.Collection(r.collection).DeleteOne(ctx, bson.M{"_id": key})
+	if err != nil {
+		return fmt.Errorf("login attempts have not been reset for key: %s, err: %w", key, err)
+	}
+	return nil
+}
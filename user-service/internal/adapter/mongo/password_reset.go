@@ -0,0 +1,90 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/adapter/mongo/dao"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+type PasswordResetRepo struct {
+	conn       *mongo.Database
+	collection string
+}
+
+func NewPasswordResetRepo(conn *mongo.Database) *PasswordResetRepo {
+	return &PasswordResetRepo{
+		conn:       conn,
+		collection: CollectionPasswordResets,
+	}
+}
+
+// EnsureIndexes lets mongo drop expired tokens on its own and speeds up lookups by user
+func (r *PasswordResetRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.conn.Collection(r.collection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+		{
+			Keys: bson.D{{Key: "userId", Value: 1}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create password reset indexes: %w", err)
+	}
+	return nil
+}
+
+func (r *PasswordResetRepo) Create(ctx context.Context, token domain.PasswordResetToken) error {
+	_, err := r.conn.Collection(r.collection).InsertOne(ctx, dao.FromPasswordResetToken(token))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Get returns an unexpired token without using it up
+func (r *PasswordResetRepo) Get(ctx context.Context, tokenHash string, now time.Time) (domain.PasswordResetToken, error) {
+	var tokenDao dao.PasswordResetToken
+	err := r.conn.Collection(r.collection).FindOne(
+		ctx,
+		bson.M{"_id": tokenHash, "expiresAt": bson.M{"$gt": now}},
+	).Decode(&tokenDao)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.PasswordResetToken{}, domain.ErrInvalidToken
+		}
+		return domain.PasswordResetToken{}, err
+	}
+	return dao.ToPasswordResetToken(tokenDao), nil
+}
+
+// Consume atomically removes an unexpired token so it can never be used twice
+func (r *PasswordResetRepo) Consume(ctx context.Context, tokenHash string, now time.Time) (domain.PasswordResetToken, error) {
+	var tokenDao dao.PasswordResetToken
+	err := r.conn.Collection(r.collection).FindOneAndDelete(
+		ctx,
+		bson.M{"_id": tokenHash, "expiresAt": bson.M{"$gt": now}},
+	).Decode(&tokenDao)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.PasswordResetToken{}, domain.ErrInvalidToken
+		}
+		return domain.PasswordResetToken{}, err
+	}
+	return dao.ToPasswordResetToken(tokenDao), nil
+}
+
+func (r *PasswordResetRepo) DeleteByUser(ctx context.Context, userID uint64) error {
+	_, err := r.conn.Collection(r.collection).DeleteMany(ctx, bson.M{"userId": userID})
+	if err != nil {
+		return fmt.Errorf("password reset tokens have not been deleted for user: %d, err: %w", userID, err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/adapter/mongo/dao"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
+	"time"
+)
+
+type SessionRepo struct {
+	conn       *mongo.Database
+	collection string
+}
+
+func NewSessionRepo(conn *mongo.Database) *SessionRepo {
+	return &SessionRepo{
+		conn:       conn,
+		collection: CollectionSessions,
+	}
+}
+
+// EnsureIndexes lets mongo drop expired sessions on its own and speeds up listing by user
+func (r *SessionRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.conn.Collection(r.collection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+		{
+			Keys: bson.D{{Key: "userId", Value: 1}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create session indexes: %w", err)
+	}
+	return nil
+}
+
+func (r *SessionRepo) Create(ctx context.Context, session domain.Session) error {
+	_, err := r.conn.Collection(r.collection).InsertOne(ctx, dao.FromSession(session))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *SessionRepo) GetWithFilter(ctx context.Context, filter domain.SessionFilter) (domain.Session, error) {
+	var sessionDao dao.Session
+	err := r.conn.Collection(r.collection).FindOne(
+		ctx,
+		dao.FromSessionFilter(filter),
+	).Decode(&sessionDao)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.Session{}, domain.ErrSessionNotFound
+		}
+		return domain.Session{}, err
+	}
+	return dao.ToSession(sessionDao), nil
+}
+
+func (r *SessionRepo) GetListWithFilter(ctx context.Context, filter domain.SessionFilter) ([]domain.Session, error) {
+	cursor, err := r.conn.Collection(r.collection).Find(
+		ctx,
+		dao.FromSessionFilter(filter),
+		options.Find().SetSort(bson.D{{Key: "lastSeenAt", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("failed to close cursor: %v", err)
+		}
+	}(cursor, ctx)
+
+	var sessionDaos []dao.Session
+	if err = cursor.All(ctx, &sessionDaos); err != nil {
+		return nil, err
+	}
+
+	sessions := make([]domain.Session, 0, len(sessionDaos))
+	for _, sessionDao := range sessionDaos {
+		sessions = append(sessions, dao.ToSession(sessionDao))
+	}
+	return sessions, nil
+}
+
+func (r *SessionRepo) Update(ctx context.Context, filter domain.SessionFilter, update domain.SessionUpdate) error {
+	res, err := r.conn.Collection(r.collection).UpdateOne(
+		ctx,
+		dao.FromSessionFilter(filter),
+		dao.FromSessionUpdate(update),
+	)
+	if err != nil {
+		return fmt.Errorf("session has not been updated with filter: %v, err: %w", filter, err)
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// Rotate replaces the refresh token of an active session, it fails with domain.ErrInvalidToken if the
+// presented token is not the latest one issued for the session or the session has been revoked
+func (r *SessionRepo) Rotate(ctx context.Context, sessionID, oldHash, newHash, ip string, expiresAt, now time.Time) error {
+	res, err := r.conn.Collection(r.collection).UpdateOne(
+		ctx,
+		bson.M{"_id": sessionID, "refreshTokenHash": oldHash, "revokedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{
+			"refreshTokenHash": newHash,
+			"ip":               ip,
+			"lastSeenAt":       now,
+			"expiresAt":        expiresAt,
+		}},
+	)
+	if err != nil {
+		return fmt.Errorf("session has not been rotated: %s, err: %w", sessionID, err)
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrInvalidToken
+	}
+
+	return nil
+}
+
+// RevokeAll revokes every active session of the user except the given one and returns how many were revoked
+func (r *SessionRepo) RevokeAll(ctx context.Context, userID uint64, exceptID string, now time.Time) (int64, error) {
+	query := bson.M{"userId": userID, "revokedAt": bson.M{"$exists": false}}
+	if exceptID != "" {
+		query["_id"] = bson.M{"$ne": exceptID}
+	}
+
+	res, err := r.conn.Collection(r.collection).UpdateMany(ctx, query, bson.M{"$set": bson.M{"revokedAt": now}})
+	if err != nil {
+		return 0, fmt.Errorf("sessions have not been revoked for user: %d, err: %w", userID, err)
+	}
+	return res.ModifiedCount, nil
+}
+
+// DeleteByUser removes all sessions of the user along with the devices and addresses they were used from
+func (r *SessionRepo) DeleteByUser(ctx context.Context, userID uint64) error {
+	_, err := r.conn.Collection(r.collection).DeleteMany(ctx, bson.M{"userId": userID})
+	if err != nil {
+		return fmt.Errorf("sessions have not been deleted for user: %d, err: %w", userID, err)
+	}
+	return nil
+}
@@ -2,9 +2,14 @@ package mongo
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/BeksultanSE/Assignment1-user/internal/adapter/mongo/dao"
 	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"log"
 )
 
 type UserRepo struct {
@@ -19,6 +24,25 @@ func NewUserRepo(conn *mongo.Database) *UserRepo {
 	}
 }
 
+// EnsureIndexes speeds up user lookups by email and the admin user listing
+func (u *UserRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := u.conn.Collection(u.collection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "email", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user indexes: %w", err)
+	}
+	return nil
+}
+
 func (u *UserRepo) Create(ctx context.Context, user domain.User) error {
 	newUser := dao.FromUser(user)
 	_, err := u.conn.Collection(u.collection).InsertOne(ctx, newUser)
@@ -35,14 +59,111 @@ func (u *UserRepo) GetWithFilter(ctx context.Context, filter domain.UserFilter)
 		dao.FromUserFilter(filter),
 	).Decode(&userDao)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.User{}, domain.ErrUserNotFound
+		}
 		return domain.User{}, err
 	}
 	return dao.ToUser(userDao), nil
 }
 
+// GetListWithFilter retrieves a page of users matching the filter in the given order
+func (u *UserRepo) GetListWithFilter(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, page, limit int64) ([]domain.User, int, error) {
+	findFilter := dao.FromUserFilter(filter)
+
+	findOptions := options.Find().
+		SetSort(dao.FromUserSort(sort)).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+
+	totalCount, err := u.conn.Collection(u.collection).CountDocuments(ctx, findFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursor, err := u.conn.Collection(u.collection).Find(ctx, findFilter, findOptions)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find users: %w", err)
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		if err := cursor.Close(ctx); err != nil {
+			log.Printf("failed to close cursor: %v", err)
+		}
+	}(cursor, ctx)
+
+	var userDaos []dao.User
+	if err := cursor.All(ctx, &userDaos); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode users: %w", err)
+	}
+	return dao.ToUserList(userDaos), int(totalCount), nil
+}
+
 func (u *UserRepo) Update(ctx context.Context, filter domain.UserFilter, update domain.UserUpdate) error {
-	panic("implement me")
+	res, err := u.conn.Collection(u.collection).UpdateOne(
+		ctx,
+		dao.FromUserFilter(filter),
+		dao.FromUserUpdate(update),
+	)
+	if err != nil {
+		return fmt.Errorf("user has not been updated with filter: %v, err: %w", filter, err)
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
 }
+
 func (u *UserRepo) Delete(ctx context.Context, filter domain.UserFilter) error {
-	panic("implement me")
+	res, err := u.conn.Collection(u.collection).DeleteOne(
+		ctx,
+		dao.FromUserFilter(filter),
+	)
+	if err != nil {
+		return fmt.Errorf("user has not been deleted with filter: %v, err: %w", filter, err)
+	}
+
+	if res.DeletedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UseTwoFactorStep records the TOTP step of an accepted code, it fails with domain.ErrInvalidTwoFactorCode
+// if the same or a later step has already been used
+func (u *UserRepo) UseTwoFactorStep(ctx context.Context, userID uint64, step int64) error {
+	res, err := u.conn.Collection(u.collection).UpdateOne(
+		ctx,
+		bson.M{"_id": userID, "twoFactor.lastUsedStep": bson.M{"$lt": step}},
+		bson.M{"$set": bson.M{"twoFactor.lastUsedStep": step}},
+	)
+	if err != nil {
+		return fmt.Errorf("two-factor step has not been recorded for user: %d, err: %w", userID, err)
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+// UseRecoveryCode removes the recovery code, it fails with domain.ErrInvalidTwoFactorCode if the code is unknown or used
+func (u *UserRepo) UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) error {
+	res, err := u.conn.Collection(u.collection).UpdateOne(
+		ctx,
+		bson.M{"_id": userID, "twoFactor.recoveryCodes": codeHash},
+		bson.M{"$pull": bson.M{"twoFactor.recoveryCodes": codeHash}},
+	)
+	if err != nil {
+		return fmt.Errorf("recovery code has not been used for user: %d, err: %w", userID, err)
+	}
+
+	if res.MatchedCount == 0 {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	return nil
 }
@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/config"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogNotifier is a development notifier, it writes notifications to a local outbox file
+// or to the service log instead of delivering them
+type LogNotifier struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewLogNotifier appends to cfg.OutboxPath, or falls back to the service log if the path is empty
+func NewLogNotifier(cfg config.Notifier) (*LogNotifier, error) {
+	if cfg.OutboxPath == "" {
+		return &LogNotifier{out: log.Writer()}, nil
+	}
+
+	file, err := os.OpenFile(cfg.OutboxPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open notifier outbox: %w", err)
+	}
+	return &LogNotifier{out: file}, nil
+}
+
+func (n *LogNotifier) Notify(_ context.Context, notification domain.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	_, err := fmt.Fprintf(n.out, "--- %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().Format(time.RFC3339), notification.To, notification.Subject, notification.Body)
+	if err != nil {
+		return fmt.Errorf("failed to write notification: %w", err)
+	}
+	return nil
+}
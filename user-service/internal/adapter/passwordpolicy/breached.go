@@ -0,0 +1,130 @@
+package passwordpolicy
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	sha1HexLength = 40
+	prefixLength  = 5 // length of the hash prefixes HIBP range files are named after
+)
+
+// breachedList reports how often the password with the given upper-case SHA1 hash has appeared in breaches
+type breachedList interface {
+	count(hash string) (int, error)
+}
+
+// hashList is a list of "HASH[:COUNT]" lines loaded into memory, indexed by hash prefix
+type hashList map[string]map[string]int
+
+func loadHashList(path string) (hashList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password list: %w", err)
+	}
+	defer file.Close()
+
+	list := hashList{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		hash, count, ok, err := parseLine(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("invalid breached password list entry on line %d: %w", line, err)
+		}
+		if !ok {
+			continue
+		}
+		if len(hash) != sha1HexLength {
+			return nil, fmt.Errorf("invalid breached password list entry on line %d: not a SHA1 hash", line)
+		}
+
+		prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+		if list[prefix] == nil {
+			list[prefix] = map[string]int{}
+		}
+		list[prefix][suffix] += count
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breached password list: %w", err)
+	}
+	return list, nil
+}
+
+func (l hashList) count(hash string) (int, error) {
+	return l[hash[:prefixLength]][hash[prefixLength:]], nil
+}
+
+// rangeDirectory is a local copy of the HIBP range API: one file per hash prefix, e.g. "21BD1",
+// holding "SUFFIX:COUNT" lines. Files are read on demand, so the full dataset never has to fit in memory.
+type rangeDirectory struct {
+	root string
+}
+
+func (d rangeDirectory) count(hash string) (int, error) {
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+
+	file, err := os.Open(filepath.Join(d.root, prefix))
+	if errors.Is(err, fs.ErrNotExist) {
+		file, err = os.Open(filepath.Join(d.root, prefix+".txt"))
+	}
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to open breached password range %s: %w", prefix, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry, count, ok, err := parseLine(scanner.Text())
+		if err != nil || !ok {
+			continue
+		}
+		if entry == suffix {
+			return count, nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read breached password range %s: %w", prefix, err)
+	}
+	return 0, nil
+}
+
+// parseLine reads a "HASH[:COUNT]" entry, blank lines and # comments are skipped
+func parseLine(line string) (string, int, bool, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", 0, false, nil
+	}
+
+	hash, rawCount, hasCount := strings.Cut(line, ":")
+	hash = strings.ToUpper(strings.TrimSpace(hash))
+	if hash == "" || strings.Trim(hash, "0123456789ABCDEF") != "" {
+		return "", 0, false, errors.New("hash is not hexadecimal")
+	}
+
+	count := 1
+	if hasCount {
+		n, err := strconv.Atoi(strings.TrimSpace(rawCount))
+		if err != nil || n < 0 {
+			return "", 0, false, errors.New("count is not a number")
+		}
+		count = n
+	}
+	return hash, count, true, nil
+}
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
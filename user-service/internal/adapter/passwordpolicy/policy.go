@@ -0,0 +1,144 @@
+package passwordpolicy
+
+import (
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/config"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minPersonalInfoLength keeps very short names from rejecting unrelated passwords
+const minPersonalInfoLength = 3
+
+// Policy checks new passwords against the configured rules and an optional breached-password list
+type Policy struct {
+	cfg      config.PasswordPolicy
+	breached breachedList
+}
+
+// NewPolicy loads the breached-password list from cfg.BreachedListPath if it is set
+func NewPolicy(cfg config.PasswordPolicy) (*Policy, error) {
+	policy := &Policy{cfg: cfg}
+	if cfg.BreachedListPath == "" {
+		return policy, nil
+	}
+
+	info, err := os.Stat(cfg.BreachedListPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password list: %w", err)
+	}
+
+	if info.IsDir() {
+		policy.breached = rangeDirectory{root: cfg.BreachedListPath}
+		return policy, nil
+	}
+
+	list, err := loadHashList(cfg.BreachedListPath)
+	if err != nil {
+		return nil, err
+	}
+	policy.breached = list
+	return policy, nil
+}
+
+// Check returns every rule the password violates. The name and email of the user are used to reject
+// passwords built from personal information.
+func (p *Policy) Check(password string, user domain.User) ([]domain.PasswordViolation, error) {
+	var violations []domain.PasswordViolation
+
+	length := utf8.RuneCountInString(password)
+	if length < p.cfg.MinLength {
+		violations = append(violations, domain.PasswordViolation{
+			Rule:    domain.PasswordRuleMinLength,
+			Message: fmt.Sprintf("must be at least %d characters long", p.cfg.MinLength),
+		})
+	}
+	if p.cfg.MaxLength > 0 && length > p.cfg.MaxLength {
+		violations = append(violations, domain.PasswordViolation{
+			Rule:    domain.PasswordRuleMaxLength,
+			Message: fmt.Sprintf("must be at most %d characters long", p.cfg.MaxLength),
+		})
+	}
+
+	if characterClasses(password) < p.cfg.MinCharacterClasses {
+		violations = append(violations, domain.PasswordViolation{
+			Rule: domain.PasswordRuleCharacterClasses,
+			Message: fmt.Sprintf("must contain at least %d of: lowercase letters, uppercase letters, digits, symbols",
+				p.cfg.MinCharacterClasses),
+		})
+	}
+
+	if p.cfg.RejectPersonalInfo && containsPersonalInfo(password, user) {
+		violations = append(violations, domain.PasswordViolation{
+			Rule:    domain.PasswordRulePersonalInfo,
+			Message: "must not contain your name or email",
+		})
+	}
+
+	if p.breached != nil {
+		count, err := p.breached.count(sha1Hex(password))
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 && count >= p.cfg.BreachedMinCount {
+			violations = append(violations, domain.PasswordViolation{
+				Rule:    domain.PasswordRuleBreached,
+				Message: "has appeared in a data breach, choose a different one",
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// characterClasses counts the classes among lowercase letters, uppercase letters, digits and symbols
+// present in the password
+func characterClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			classes++
+		}
+	}
+	return classes
+}
+
+// containsPersonalInfo reports whether the password contains the email, its local part or a part of the name
+func containsPersonalInfo(password string, user domain.User) bool {
+	password = strings.ToLower(password)
+
+	var parts []string
+	if email := strings.ToLower(user.Email); email != "" {
+		parts = append(parts, email)
+		if local, _, found := strings.Cut(email, "@"); found {
+			parts = append(parts, local)
+		}
+	}
+	parts = append(parts, strings.FieldsFunc(strings.ToLower(user.Name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})...)
+
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) >= minPersonalInfoLength && strings.Contains(password, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/config"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"github.com/golang-jwt/jwt/v5"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const algorithm = "EdDSA"
+
+// claims is the JWT payload issued by the user-service
+type claims struct {
+	Type domain.TokenType `json:"type"`
+	Role domain.Role      `json:"role,omitempty"`
+	MFA  bool             `json:"mfa,omitempty"`
+	SID  string           `json:"sid,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// JWTManager signs and verifies ed25519 JWTs
+type JWTManager struct {
+	privateKey   ed25519.PrivateKey
+	publicKey    ed25519.PublicKey
+	keyID        string
+	issuer       string
+	accessTTL    time.Duration
+	refreshTTL   time.Duration
+	twoFactorTTL time.Duration
+}
+
+// NewJWTManager loads the signing key from cfg.PrivateKeyPath, or generates a new one if the path is empty
+func NewJWTManager(cfg config.Token) (*JWTManager, error) {
+	privateKey, err := loadPrivateKey(cfg.PrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	sum := sha256.Sum256(publicKey)
+
+	return &JWTManager{
+		privateKey:   privateKey,
+		publicKey:    publicKey,
+		keyID:        hex.EncodeToString(sum[:8]),
+		issuer:       cfg.Issuer,
+		accessTTL:    cfg.AccessTTL,
+		refreshTTL:   cfg.RefreshTTL,
+		twoFactorTTL: cfg.TwoFactorTTL,
+	}, nil
+}
+
+// Issue signs a new token of the given type, expiry is set from the configured TTL
+func (m *JWTManager) Issue(tc domain.TokenClaims) (string, error) {
+	ttl := m.accessTTL
+	switch tc.Type {
+	case domain.RefreshToken:
+		ttl = m.refreshTTL
+	case domain.TwoFactorToken:
+		ttl = m.twoFactorTTL
+	}
+
+	// a unique id keeps tokens issued within the same second apart, refresh rotation relies on it
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
+
+	now := time.Now()
+	jwtClaims := claims{
+		Type: tc.Type,
+		Role: tc.Role,
+		MFA:  tc.TwoFactor,
+		SID:  tc.SessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        hex.EncodeToString(jti),
+			Issuer:    m.issuer,
+			Subject:   strconv.FormatUint(tc.UserID, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwtClaims)
+	t.Header["kid"] = m.keyID
+
+	signed, err := t.SignedString(m.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signed, nil
+}
+
+// Parse verifies the signature, expiry and type of the token
+func (m *JWTManager) Parse(tokenStr string, tokenType domain.TokenType) (domain.TokenClaims, error) {
+	var jwtClaims claims
+	_, err := jwt.ParseWithClaims(tokenStr, &jwtClaims, func(t *jwt.Token) (interface{}, error) {
+		return m.publicKey, nil
+	},
+		jwt.WithValidMethods([]string{algorithm}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return domain.TokenClaims{}, domain.ErrInvalidToken
+	}
+
+	if jwtClaims.Type != tokenType {
+		return domain.TokenClaims{}, domain.ErrInvalidToken
+	}
+
+	userID, err := strconv.ParseUint(jwtClaims.Subject, 10, 64)
+	if err != nil {
+		return domain.TokenClaims{}, domain.ErrInvalidToken
+	}
+
+	return domain.TokenClaims{
+		UserID:    userID,
+		Role:      jwtClaims.Role,
+		Type:      jwtClaims.Type,
+		TwoFactor: jwtClaims.MFA,
+		SessionID: jwtClaims.SID,
+		ExpiresAt: jwtClaims.ExpiresAt.Time,
+	}, nil
+}
+
+func (m *JWTManager) AccessTTL() time.Duration {
+	return m.accessTTL
+}
+
+func (m *JWTManager) RefreshTTL() time.Duration {
+	return m.refreshTTL
+}
+
+// PublicKey returns the PEM encoded verification key
+func (m *JWTManager) PublicKey() domain.PublicKey {
+	der, err := x509.MarshalPKIXPublicKey(m.publicKey)
+	if err != nil {
+		// ed25519 keys are always marshallable
+		panic(err)
+	}
+
+	return domain.PublicKey{
+		KeyID:     m.keyID,
+		Algorithm: algorithm,
+		PEM:       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}
+}
+
+func loadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	if path == "" {
+		log.Println("TOKEN_PRIVATE_KEY_PATH is not set, generating an ephemeral signing key")
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate signing key: %w", err)
+		}
+		return privateKey, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode signing key: no PEM block found")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("signing key is not an ed25519 key")
+	}
+	return privateKey, nil
+}
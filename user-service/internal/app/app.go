@@ -5,10 +5,17 @@ import (
 	"fmt"
 	"github.com/BeksultanSE/Assignment1-user/config"
 	"github.com/BeksultanSE/Assignment1-user/internal/adapter/grpc"
+	"github.com/BeksultanSE/Assignment1-user/internal/adapter/grpc/clients"
+	"github.com/BeksultanSE/Assignment1-user/internal/adapter/kafka"
 	"github.com/BeksultanSE/Assignment1-user/internal/adapter/mongo"
+	"github.com/BeksultanSE/Assignment1-user/internal/adapter/notifier"
+	"github.com/BeksultanSE/Assignment1-user/internal/adapter/passwordpolicy"
+	"github.com/BeksultanSE/Assignment1-user/internal/adapter/token"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
 	"github.com/BeksultanSE/Assignment1-user/internal/usecase"
 	"github.com/BeksultanSE/Assignment1-user/pkg/hashing"
 	mongoConn "github.com/BeksultanSE/Assignment1-user/pkg/mongo"
+	"github.com/BeksultanSE/Assignment1-user/pkg/twofactor"
 	"log"
 	"os"
 	"os/signal"
@@ -18,7 +25,9 @@ import (
 const serviceName = "user-service"
 
 type App struct {
-	grpcServer *grpc.ServerAPI
+	grpcServer  *grpc.ServerAPI
+	grpcClients *clients.Clients
+	kafkaProd   *kafka.Producer
 }
 
 func NewApp(ctx context.Context, cfg *config.Config) (*App, error) {
@@ -32,15 +41,118 @@ func NewApp(ctx context.Context, cfg *config.Config) (*App, error) {
 
 	aiRepo := mongo.NewAutoInc(mongoDB.Conn)
 	userRepo := mongo.NewUserRepo(mongoDB.Conn)
+	if err = userRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("error preparing user collection: %v", err)
+	}
+	resetRepo := mongo.NewPasswordResetRepo(mongoDB.Conn)
+	if err = resetRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("error preparing password reset collection: %v", err)
+	}
+	verifyRepo := mongo.NewEmailVerificationRepo(mongoDB.Conn)
+	if err = verifyRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("error preparing email verification collection: %v", err)
+	}
+	attemptRepo := mongo.NewLoginAttemptRepo(mongoDB.Conn)
+	if err = attemptRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("error preparing login attempt collection: %v", err)
+	}
+	apiKeyRepo := mongo.NewAPIKeyRepo(mongoDB.Conn)
+	if err = apiKeyRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("error preparing api key collection: %v", err)
+	}
+	sessionRepo := mongo.NewSessionRepo(mongoDB.Conn)
+	if err = sessionRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("error preparing session collection: %v", err)
+	}
+	addressRepo := mongo.NewAddressRepo(mongoDB.Conn)
+	if err = addressRepo.EnsureIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("error preparing address collection: %v", err)
+	}
+
+	grpcClients, err := clients.NewClients(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing gRPC clients: %v", err)
+	}
+
+	producer, err := kafka.NewKafkaProducer(cfg.Brokers)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing kafka producer: %v", err)
+	}
 
-	hasher := hashing.NewBcryptHasher()
+	hasher, err := hashing.NewHasher(cfg.Hashing)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing password hasher: %v", err)
+	}
 
-	userUsecase := usecase.NewUserUsecase(aiRepo, userRepo, hasher)
+	passwordPolicy, err := passwordpolicy.NewPolicy(cfg.PasswordPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing password policy: %v", err)
+	}
 
-	grpcServer := grpc.New(cfg.Server, userUsecase)
+	tokenManager, err := token.NewJWTManager(cfg.Token)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing token manager: %v", err)
+	}
+
+	notify, err := notifier.NewLogNotifier(cfg.Notifier)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing notifier: %v", err)
+	}
+
+	userUsecase := usecase.NewUserUsecase(
+		aiRepo,
+		userRepo,
+		resetRepo,
+		verifyRepo,
+		attemptRepo,
+		sessionRepo,
+		addressRepo,
+		apiKeyRepo,
+		hasher,
+		passwordPolicy,
+		tokenManager,
+		twofactor.NewTOTP(cfg.TwoFactor.Issuer),
+		notify,
+		producer,
+		cfg.PasswordReset.TokenTTL,
+		cfg.EmailVerification.TokenTTL,
+		domain.LockoutPolicy{
+			MaxAccountFailures: cfg.Lockout.MaxAccountFailures,
+			MaxIPFailures:      cfg.Lockout.MaxIPFailures,
+			FailureWindow:      cfg.Lockout.FailureWindow,
+			BaseDuration:       cfg.Lockout.BaseDuration,
+			MaxDuration:        cfg.Lockout.MaxDuration,
+			ResetAfter:         cfg.Lockout.ResetAfter,
+		},
+	)
+
+	if cfg.Bootstrap.AdminEmail != "" {
+		// the account may not be registered or verified yet, it is promoted on a later start
+		if err = userUsecase.BootstrapAdmin(ctx, cfg.Bootstrap.AdminEmail); err != nil {
+			log.Printf("failed to bootstrap admin %s: %v", cfg.Bootstrap.AdminEmail, err)
+		}
+	}
+
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(aiRepo, apiKeyRepo, userRepo)
+	addressUsecase := usecase.NewAddressUsecase(aiRepo, addressRepo, userRepo)
+	privacyUsecase := usecase.NewPrivacyUsecase(
+		userRepo,
+		addressRepo,
+		sessionRepo,
+		apiKeyRepo,
+		resetRepo,
+		verifyRepo,
+		attemptRepo,
+		clients.NewOrderClient(grpcClients.Order),
+		producer,
+	)
+
+	grpcServer := grpc.New(cfg.Server, userUsecase, apiKeyUsecase, addressUsecase, privacyUsecase)
 
 	app := &App{
-		grpcServer: grpcServer,
+		grpcServer:  grpcServer,
+		grpcClients: grpcClients,
+		kafkaProd:   producer,
 	}
 
 	return app, nil
@@ -72,4 +184,8 @@ func (app *App) Stop() {
 	if err != nil {
 		log.Printf(fmt.Sprintf("Error stopping %s service: %v", serviceName, err))
 	}
+	app.grpcClients.Close()
+	if err := app.kafkaProd.Close(); err != nil {
+		log.Printf("Error closing kafka producer: %v", err)
+	}
 }
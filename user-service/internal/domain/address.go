@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// Address is an entry of a user's address book. At most one address per user is the default
+// shipping address and at most one the default billing address.
+type Address struct {
+	ID              uint64
+	UserID          uint64
+	Label           string // e.g. "Home" or "Garage"
+	RecipientName   string
+	Phone           string
+	Line1           string
+	Line2           string
+	City            string
+	Region          string
+	PostalCode      string
+	Country         string
+	DefaultShipping bool
+	DefaultBilling  bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type AddressFilter struct {
+	ID              *uint64
+	UserID          *uint64
+	DefaultShipping *bool
+	DefaultBilling  *bool
+}
+
+type AddressUpdate struct {
+	Label           *string
+	RecipientName   *string
+	Phone           *string
+	Line1           *string
+	Line2           *string
+	City            *string
+	Region          *string
+	PostalCode      *string
+	Country         *string
+	DefaultShipping *bool
+	DefaultBilling  *bool
+	UpdatedAt       *time.Time
+}
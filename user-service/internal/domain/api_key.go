@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// APIKey is a long-lived credential for machine clients. A key without a role acts as its owner
+// with the owner's current role, a key with a role acts with that role on behalf of the owner.
+// Only the SHA-256 hash of the key is stored.
+type APIKey struct {
+	ID         uint64
+	Name       string
+	Prefix     string // first characters of the key, to tell keys apart without revealing them
+	KeyHash    string
+	OwnerID    uint64
+	Role       Role
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+}
+
+// Revoked reports whether the key can no longer be used
+func (k APIKey) Revoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+type APIKeyFilter struct {
+	ID      *uint64
+	OwnerID *uint64
+	KeyHash *string
+}
+
+type APIKeyUpdate struct {
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
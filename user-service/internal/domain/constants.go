@@ -1,5 +1,11 @@
 package domain
 
 const (
-	UserDB = "users"
+	UserDB               = "users"
+	PasswordResetsDB     = "password_resets"
+	EmailVerificationsDB = "email_verifications"
+	LoginAttemptsDB      = "login_attempts"
+	APIKeysDB            = "api_keys"
+	AddressesDB          = "addresses"
+	SessionsDB           = "sessions"
 )
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// DataExport gathers everything stored about a user for a data access request
+type DataExport struct {
+	User        User
+	Addresses   []Address
+	Sessions    []Session
+	APIKeys     []APIKey
+	Orders      []Order
+	GeneratedAt time.Time
+}
+
+// Order is an entry of the user's order history as reported by order-service
+type Order struct {
+	ID              uint64
+	Status          string
+	Items           []OrderItem
+	TotalAmount     float64
+	ShippingAddress OrderAddress
+	BillingAddress  OrderAddress
+	CreatedAt       string
+	UpdatedAt       string
+}
+
+type OrderItem struct {
+	ProductID  uint64
+	Name       string
+	Price      float64
+	Quantity   uint64
+	TotalPrice float64
+}
+
+// OrderAddress is the copy of an address book entry taken when the order was placed
+type OrderAddress struct {
+	RecipientName string
+	Phone         string
+	Line1         string
+	Line2         string
+	City          string
+	Region        string
+	PostalCode    string
+	Country       string
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// EmailVerificationToken confirms that a user owns the email address of their account.
+// As with password resets, only the SHA-256 hash of the token is stored.
+type EmailVerificationToken struct {
+	TokenHash string
+	UserID    uint64
+	Email     string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
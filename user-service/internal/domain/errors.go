@@ -7,4 +7,25 @@ var (
 	ErrInvalidPassword = errors.New("Invalid password")
 	ErrUserNotFound    = errors.New("User not found")
 	ErrUserExists      = errors.New("User already exists")
+	ErrInvalidToken    = errors.New("Invalid token")
+	ErrInvalidRole     = errors.New("Invalid role")
+	ErrEmailVerified   = errors.New("Email already verified")
+	ErrEmailUnverified = errors.New("Email not verified")
+
+	// ErrInvalidCredentials is returned for both unknown emails and wrong passwords
+	// so that login responses do not reveal which accounts exist
+	ErrInvalidCredentials = errors.New("Invalid credentials")
+	ErrAccountLocked      = errors.New("Too many failed login attempts, try again later")
+
+	ErrTwoFactorRequired    = errors.New("Two-factor code required")
+	ErrInvalidTwoFactorCode = errors.New("Invalid two-factor code")
+	ErrTwoFactorEnabled     = errors.New("Two-factor authentication already enabled")
+	ErrTwoFactorNotEnabled  = errors.New("Two-factor authentication not enabled")
+
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	ErrInvalidAPIKey  = errors.New("Invalid API key")
+
+	ErrAddressNotFound = errors.New("Address not found")
+
+	ErrSessionNotFound = errors.New("Session not found")
 )
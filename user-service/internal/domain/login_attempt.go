@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// LoginAttempts tracks recent failed logins for a single key, an account email or a client IP
+type LoginAttempts struct {
+	Key           string
+	Failures      int       // failures since the last lockout or success
+	Lockouts      int       // consecutive lockouts, used to grow the lockout duration
+	LockedUntil   time.Time // zero when not locked
+	LastFailureAt time.Time
+}
+
+// Locked reports whether logins for the key are refused at the given time
+func (a LoginAttempts) Locked(now time.Time) bool {
+	return a.LockedUntil.After(now)
+}
+
+// LockoutPolicy configures when and for how long logins are refused after repeated failures
+type LockoutPolicy struct {
+	MaxAccountFailures int
+	MaxIPFailures      int
+	FailureWindow      time.Duration // failures older than this are forgotten
+	BaseDuration       time.Duration // first lockout duration, doubled with every following lockout
+	MaxDuration        time.Duration
+	ResetAfter         time.Duration // lockout history is forgotten after this much time without failures
+}
+
+// Duration returns how long to lock a key that has already been locked the given number of times
+func (p LockoutPolicy) Duration(lockouts int) time.Duration {
+	d := p.BaseDuration
+	for i := 0; i < lockouts && d < p.MaxDuration; i++ {
+		d *= 2
+	}
+	if d > p.MaxDuration {
+		d = p.MaxDuration
+	}
+	return d
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "strings"
+
+// Password policy rules a new password can violate
+const (
+	PasswordRuleMinLength        = "min_length"
+	PasswordRuleMaxLength        = "max_length"
+	PasswordRuleCharacterClasses = "character_classes"
+	PasswordRulePersonalInfo     = "personal_info"
+	PasswordRuleBreached         = "breached"
+)
+
+// PasswordViolation describes a rule of the password policy that a password does not satisfy
+type PasswordViolation struct {
+	Rule    string
+	Message string
+}
+
+// PasswordPolicyError is returned when a new password is rejected by the password policy
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e PasswordPolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+	return "Password does not meet the policy: " + strings.Join(messages, "; ")
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// PasswordResetToken is a single-use token allowing a user to set a new password.
+// Only the SHA-256 hash of the token is stored, the raw value is delivered to the user.
+type PasswordResetToken struct {
+	TokenHash string
+	UserID    uint64
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// Notification is a message delivered to a user outside the API, e.g. by email
+type Notification struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -0,0 +1,20 @@
+package domain
+
+type Role string
+
+const (
+	RoleCustomer   Role = "customer"
+	RoleSalesStaff Role = "sales_staff"
+	RoleWarehouse  Role = "warehouse"
+	RoleAdmin      Role = "admin"
+)
+
+// Valid reports whether r is one of the known roles
+func (r Role) Valid() bool {
+	switch r {
+	case RoleCustomer, RoleSalesStaff, RoleWarehouse, RoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
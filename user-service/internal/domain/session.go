@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// Session is a login on one device. Access and refresh tokens carry the session id, so revoking
+// the session signs the device out. The refresh token is rotated on every use and only the hash
+// of the latest one is stored.
+type Session struct {
+	ID               string
+	UserID           uint64
+	UserAgent        string
+	IP               string
+	TwoFactor        bool // the login was confirmed with a second factor
+	RefreshTokenHash string
+	CreatedAt        time.Time
+	LastSeenAt       time.Time
+	ExpiresAt        time.Time
+	RevokedAt        time.Time
+}
+
+// Revoked reports whether the session was signed out
+func (s Session) Revoked() bool {
+	return !s.RevokedAt.IsZero()
+}
+
+// Active reports whether tokens of the session are still accepted
+func (s Session) Active(now time.Time) bool {
+	return !s.Revoked() && now.Before(s.ExpiresAt)
+}
+
+// ClientInfo describes the device a request was made from
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+type SessionFilter struct {
+	ID     *string
+	UserID *uint64
+}
+
+type SessionUpdate struct {
+	LastSeenAt *time.Time
+	RevokedAt  *time.Time
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+type TokenType string
+
+const (
+	AccessToken  TokenType = "access"
+	RefreshToken TokenType = "refresh"
+	// TwoFactorToken is a short-lived token proving the password step of a login, exchanged for a token pair with a TOTP code
+	TwoFactorToken TokenType = "two_factor"
+)
+
+// TokenClaims represents the data carried inside a signed token
+type TokenClaims struct {
+	UserID    uint64
+	Role      Role
+	Type      TokenType
+	TwoFactor bool   // the login was confirmed with a second factor
+	SessionID string // session the access and refresh tokens belong to
+	ExpiresAt time.Time
+}
+
+// TokenPair is returned to the client after a successful login or refresh
+type TokenPair struct {
+	UserID       uint64
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+
+	// set instead of the tokens above when the login still needs a second factor
+	TwoFactorRequired bool
+	TwoFactorToken    string
+}
+
+// PublicKey is the verification key other services use to check access tokens locally
+type PublicKey struct {
+	KeyID     string
+	Algorithm string
+	PEM       string
+}
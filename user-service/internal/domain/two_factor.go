@@ -0,0 +1,16 @@
+package domain
+
+// TwoFactor holds the TOTP second factor settings of a user
+type TwoFactor struct {
+	Enabled       bool
+	Secret        string   // base32 TOTP secret, set once enrollment is confirmed
+	PendingSecret string   // secret handed out by an enrollment that has not been confirmed yet
+	RecoveryCodes []string // SHA-256 hashes of the unused recovery codes
+	LastUsedStep  int64    // TOTP time step of the last accepted code, older codes are rejected as replays
+}
+
+// TwoFactorEnrollment is returned when a user starts enrolling an authenticator app
+type TwoFactorEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
@@ -7,19 +7,57 @@ type User struct {
 	Name           string
 	Email          string
 	HashedPassword string
+	Role           Role
+	EmailVerified  bool
+	TwoFactor      TwoFactor
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	ErasedAt       time.Time // set once the personal data has been erased, the record is kept anonymized
 }
 
 type UserFilter struct {
 	ID    *uint64
 	Name  *string
 	Email *string
+
+	// used by listings
+	Search      *string // case-insensitive partial match on name or email
+	Role        *Role
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+// UserSortField lists the fields users can be ordered by
+type UserSortField string
+
+const (
+	UserSortByID        UserSortField = "id"
+	UserSortByName      UserSortField = "name"
+	UserSortByEmail     UserSortField = "email"
+	UserSortByCreatedAt UserSortField = "created_at"
+)
+
+// Valid reports whether the field is one of the supported sort fields
+func (f UserSortField) Valid() bool {
+	switch f {
+	case UserSortByID, UserSortByName, UserSortByEmail, UserSortByCreatedAt:
+		return true
+	}
+	return false
+}
+
+type UserSort struct {
+	Field      UserSortField
+	Descending bool
 }
 
 type UserUpdate struct {
 	Name           *string
 	Email          *string
 	HashedPassword *string
+	Role           *Role
+	EmailVerified  *bool
+	TwoFactor      *TwoFactor
 	UpdatedAt      *time.Time
+	ErasedAt       *time.Time
 }
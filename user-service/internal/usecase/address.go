@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"time"
+)
+
+type AddressUsecase struct {
+	aiRepo      AutoIncRepo
+	addressRepo AddressRepo
+	userRepo    UserRepo
+}
+
+func NewAddressUsecase(ai AutoIncRepo, addressRepo AddressRepo, userRepo UserRepo) AddressUsecase {
+	return AddressUsecase{
+		aiRepo:      ai,
+		addressRepo: addressRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// Create adds an address to the user's address book. The first address becomes the default
+// shipping and billing address, a new default replaces the previous one.
+func (uc AddressUsecase) Create(ctx context.Context, req domain.Address) (domain.Address, error) {
+	if _, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{ID: &req.UserID}); err != nil {
+		return domain.Address{}, err
+	}
+
+	existing, err := uc.addressRepo.GetListWithFilter(ctx, domain.AddressFilter{UserID: &req.UserID})
+	if err != nil {
+		return domain.Address{}, err
+	}
+	if len(existing) == 0 {
+		req.DefaultShipping = true
+		req.DefaultBilling = true
+	}
+
+	id, err := uc.aiRepo.Next(ctx, domain.AddressesDB)
+	if err != nil {
+		return domain.Address{}, err
+	}
+
+	now := time.Now()
+	req.ID = id
+	req.CreatedAt = now
+	req.UpdatedAt = now
+
+	if err = uc.addressRepo.Create(ctx, req); err != nil {
+		return domain.Address{}, err
+	}
+
+	if err = uc.addressRepo.ClearDefaults(ctx, req.UserID, req.ID, req.DefaultShipping, req.DefaultBilling); err != nil {
+		return domain.Address{}, err
+	}
+
+	return req, nil
+}
+
+func (uc AddressUsecase) Get(ctx context.Context, userID, addressID uint64) (domain.Address, error) {
+	return uc.addressRepo.GetWithFilter(ctx, domain.AddressFilter{ID: &addressID, UserID: &userID})
+}
+
+func (uc AddressUsecase) List(ctx context.Context, userID uint64) ([]domain.Address, error) {
+	return uc.addressRepo.GetListWithFilter(ctx, domain.AddressFilter{UserID: &userID})
+}
+
+// Update changes an address of the user, marking it as a default replaces the previous default
+func (uc AddressUsecase) Update(ctx context.Context, userID, addressID uint64, update domain.AddressUpdate) (domain.Address, error) {
+	filter := domain.AddressFilter{ID: &addressID, UserID: &userID}
+
+	now := time.Now()
+	update.UpdatedAt = &now
+
+	if err := uc.addressRepo.Update(ctx, filter, update); err != nil {
+		return domain.Address{}, err
+	}
+
+	shipping := update.DefaultShipping != nil && *update.DefaultShipping
+	billing := update.DefaultBilling != nil && *update.DefaultBilling
+	if err := uc.addressRepo.ClearDefaults(ctx, userID, addressID, shipping, billing); err != nil {
+		return domain.Address{}, err
+	}
+
+	return uc.addressRepo.GetWithFilter(ctx, filter)
+}
+
+func (uc AddressUsecase) Delete(ctx context.Context, userID, addressID uint64) error {
+	return uc.addressRepo.Delete(ctx, domain.AddressFilter{ID: &addressID, UserID: &userID})
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"log"
+	"time"
+)
+
+const (
+	apiKeyPrefix       = "msk_"
+	apiKeyPrefixLength = 12 // characters of the raw key kept for display
+)
+
+type APIKeyUsecase struct {
+	aiRepo     AutoIncRepo
+	apiKeyRepo APIKeyRepo
+	userRepo   UserRepo
+}
+
+func NewAPIKeyUsecase(ai AutoIncRepo, apiKeyRepo APIKeyRepo, userRepo UserRepo) APIKeyUsecase {
+	return APIKeyUsecase{
+		aiRepo:     ai,
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// Create issues a new key for the owner and returns the raw key, which is not stored and shown only once
+func (uc APIKeyUsecase) Create(ctx context.Context, req domain.APIKey) (string, domain.APIKey, error) {
+	if req.Role != "" && !req.Role.Valid() {
+		return "", domain.APIKey{}, domain.ErrInvalidRole
+	}
+
+	if _, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{ID: &req.OwnerID}); err != nil {
+		return "", domain.APIKey{}, err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", domain.APIKey{}, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	rawKey := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	id, err := uc.aiRepo.Next(ctx, domain.APIKeysDB)
+	if err != nil {
+		return "", domain.APIKey{}, err
+	}
+
+	key := domain.APIKey{
+		ID:        id,
+		Name:      req.Name,
+		Prefix:    rawKey[:apiKeyPrefixLength],
+		KeyHash:   hashSecretToken(rawKey),
+		OwnerID:   req.OwnerID,
+		Role:      req.Role,
+		CreatedAt: time.Now(),
+	}
+
+	if err = uc.apiKeyRepo.Create(ctx, key); err != nil {
+		return "", domain.APIKey{}, err
+	}
+
+	return rawKey, key, nil
+}
+
+func (uc APIKeyUsecase) List(ctx context.Context, ownerID uint64) ([]domain.APIKey, error) {
+	return uc.apiKeyRepo.GetListWithFilter(ctx, domain.APIKeyFilter{OwnerID: &ownerID})
+}
+
+// Revoke permanently disables one of the owner's keys
+func (uc APIKeyUsecase) Revoke(ctx context.Context, ownerID, keyID uint64) error {
+	filter := domain.APIKeyFilter{ID: &keyID, OwnerID: &ownerID}
+	key, err := uc.apiKeyRepo.GetWithFilter(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if key.Revoked() {
+		return nil
+	}
+
+	now := time.Now()
+	return uc.apiKeyRepo.Update(ctx, filter, domain.APIKeyUpdate{RevokedAt: &now})
+}
+
+// Validate resolves a raw key to the key with its effective role filled in and records its use
+func (uc APIKeyUsecase) Validate(ctx context.Context, rawKey string) (domain.APIKey, error) {
+	keyHash := hashSecretToken(rawKey)
+	filter := domain.APIKeyFilter{KeyHash: &keyHash}
+
+	key, err := uc.apiKeyRepo.GetWithFilter(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return domain.APIKey{}, domain.ErrInvalidAPIKey
+		}
+		return domain.APIKey{}, err
+	}
+
+	if key.Revoked() {
+		return domain.APIKey{}, domain.ErrInvalidAPIKey
+	}
+
+	owner, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{ID: &key.OwnerID})
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.APIKey{}, domain.ErrInvalidAPIKey
+		}
+		return domain.APIKey{}, err
+	}
+
+	// user scoped keys follow role changes of their owner
+	if key.Role == "" {
+		key.Role = owner.Role
+	}
+
+	// the timestamp is informational, a failed write does not reject the request
+	now := time.Now()
+	if err = uc.apiKeyRepo.Update(ctx, filter, domain.APIKeyUpdate{LastUsedAt: &now}); err != nil {
+		log.Printf("failed to record use of api key %d: %v", key.ID, err)
+	}
+	key.LastUsedAt = now
+
+	return key, nil
+}
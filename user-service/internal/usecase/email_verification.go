@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"time"
+)
+
+// VerifyEmail consumes a verification token and marks the owner's email as verified
+func (uc UserUsecase) VerifyEmail(ctx context.Context, rawToken string) (domain.User, error) {
+	token, err := uc.verifyRepo.Consume(ctx, hashSecretToken(rawToken), time.Now())
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	filter := domain.UserFilter{ID: &token.UserID}
+	user, err := uc.userRepo.GetWithFilter(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.User{}, domain.ErrInvalidToken
+		}
+		return domain.User{}, err
+	}
+
+	// the token was issued for an address the user no longer has
+	if user.Email != token.Email {
+		return domain.User{}, domain.ErrInvalidToken
+	}
+
+	verified := true
+	now := time.Now()
+	err = uc.userRepo.Update(ctx, filter, domain.UserUpdate{
+		EmailVerified: &verified,
+		UpdatedAt:     &now,
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	if err = uc.verifyRepo.DeleteByUser(ctx, user.ID); err != nil {
+		return domain.User{}, err
+	}
+
+	user.EmailVerified = true
+	user.UpdatedAt = now
+	uc.publishUpdated(ctx, user)
+	return user, nil
+}
+
+// ResendVerificationEmail issues a fresh verification token, invalidating the previous ones
+func (uc UserUsecase) ResendVerificationEmail(ctx context.Context, userID uint64) error {
+	user, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{ID: &userID})
+	if err != nil {
+		return err
+	}
+
+	if user.EmailVerified {
+		return domain.ErrEmailVerified
+	}
+
+	return uc.sendVerification(ctx, user)
+}
+
+func (uc UserUsecase) sendVerification(ctx context.Context, user domain.User) error {
+	if err := uc.verifyRepo.DeleteByUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	rawToken, tokenHash, err := newSecretToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = uc.verifyRepo.Create(ctx, domain.EmailVerificationToken{
+		TokenHash: tokenHash,
+		UserID:    user.ID,
+		Email:     user.Email,
+		ExpiresAt: now.Add(uc.verifyTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return err
+	}
+
+	return uc.notifier.Notify(ctx, domain.Notification{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the following token to verify your email address: %s\nIt expires in %s.",
+			user.Name, rawToken, uc.verifyTTL),
+	})
+}
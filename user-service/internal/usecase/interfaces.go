@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"time"
 )
 
 type AutoIncRepo interface {
@@ -12,11 +13,95 @@ type AutoIncRepo interface {
 type UserRepo interface {
 	Create(ctx context.Context, user domain.User) error
 	GetWithFilter(ctx context.Context, filter domain.UserFilter) (domain.User, error)
+	GetListWithFilter(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, page, limit int64) ([]domain.User, int, error)
 	Update(ctx context.Context, filter domain.UserFilter, update domain.UserUpdate) error
 	Delete(ctx context.Context, filter domain.UserFilter) error
+	UseTwoFactorStep(ctx context.Context, userID uint64, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) error
 }
 
 type PasswordHasher interface {
 	Hash(password string) (string, error)
 	Verify(hash, password string) bool
+	NeedsRehash(hash string) bool
+}
+
+type PasswordPolicy interface {
+	Check(password string, user domain.User) ([]domain.PasswordViolation, error)
+}
+
+type TokenManager interface {
+	Issue(claims domain.TokenClaims) (string, error)
+	Parse(token string, tokenType domain.TokenType) (domain.TokenClaims, error)
+	AccessTTL() time.Duration
+	RefreshTTL() time.Duration
+	PublicKey() domain.PublicKey
+}
+
+type PasswordResetRepo interface {
+	Create(ctx context.Context, token domain.PasswordResetToken) error
+	Get(ctx context.Context, tokenHash string, now time.Time) (domain.PasswordResetToken, error)
+	Consume(ctx context.Context, tokenHash string, now time.Time) (domain.PasswordResetToken, error)
+	DeleteByUser(ctx context.Context, userID uint64) error
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, notification domain.Notification) error
+}
+
+type EmailVerificationRepo interface {
+	Create(ctx context.Context, token domain.EmailVerificationToken) error
+	Consume(ctx context.Context, tokenHash string, now time.Time) (domain.EmailVerificationToken, error)
+	DeleteByUser(ctx context.Context, userID uint64) error
+}
+
+type LoginAttemptRepo interface {
+	Get(ctx context.Context, key string) (domain.LoginAttempts, error)
+	RecordFailure(ctx context.Context, key string, now time.Time, window, resetAfter time.Duration) (domain.LoginAttempts, error)
+	Lock(ctx context.Context, key string, until time.Time, resetAfter time.Duration) error
+	Reset(ctx context.Context, key string) error
+}
+
+type TOTPProvider interface {
+	GenerateSecret(accountName string) (secret string, provisioningURI string, err error)
+	Validate(secret, code string, now time.Time) (step int64, ok bool)
+}
+
+type APIKeyRepo interface {
+	Create(ctx context.Context, key domain.APIKey) error
+	GetWithFilter(ctx context.Context, filter domain.APIKeyFilter) (domain.APIKey, error)
+	GetListWithFilter(ctx context.Context, filter domain.APIKeyFilter) ([]domain.APIKey, error)
+	Update(ctx context.Context, filter domain.APIKeyFilter, update domain.APIKeyUpdate) error
+	RevokeAll(ctx context.Context, ownerID uint64, now time.Time) error
+}
+
+type AddressRepo interface {
+	Create(ctx context.Context, address domain.Address) error
+	GetWithFilter(ctx context.Context, filter domain.AddressFilter) (domain.Address, error)
+	GetListWithFilter(ctx context.Context, filter domain.AddressFilter) ([]domain.Address, error)
+	Update(ctx context.Context, filter domain.AddressFilter, update domain.AddressUpdate) error
+	ClearDefaults(ctx context.Context, userID, exceptID uint64, shipping, billing bool) error
+	Delete(ctx context.Context, filter domain.AddressFilter) error
+	DeleteByUser(ctx context.Context, userID uint64) error
+}
+
+type SessionRepo interface {
+	Create(ctx context.Context, session domain.Session) error
+	GetWithFilter(ctx context.Context, filter domain.SessionFilter) (domain.Session, error)
+	GetListWithFilter(ctx context.Context, filter domain.SessionFilter) ([]domain.Session, error)
+	Update(ctx context.Context, filter domain.SessionFilter, update domain.SessionUpdate) error
+	Rotate(ctx context.Context, sessionID, oldHash, newHash, ip string, expiresAt, now time.Time) error
+	RevokeAll(ctx context.Context, userID uint64, exceptID string, now time.Time) (int64, error)
+	DeleteByUser(ctx context.Context, userID uint64) error
+}
+
+type OrderClient interface {
+	ListUserOrders(ctx context.Context, userID uint64) ([]domain.Order, error)
+	PseudonymizeUserOrders(ctx context.Context, userID uint64) (int64, error)
+}
+
+type EventPublisher interface {
+	PublishUserRegistered(ctx context.Context, user domain.User) error
+	PublishUserUpdated(ctx context.Context, user domain.User) error
+	PublishUserDeleted(ctx context.Context, userID uint64, erased bool) error
 }
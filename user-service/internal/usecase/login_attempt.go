@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"strings"
+	"time"
+)
+
+// loginAttemptKey identifies a tracked login source along with its failure threshold
+type loginAttemptKey struct {
+	key         string
+	maxFailures int
+	account     bool
+}
+
+func accountAttemptKey(email string) string {
+	return "email:" + strings.ToLower(email)
+}
+
+// loginAttemptKeys returns the keys to track for a login. Accounts are tracked by email
+// rather than user ID so that unknown emails are locked exactly like existing ones.
+func (uc UserUsecase) loginAttemptKeys(email, clientIP string) []loginAttemptKey {
+	keys := []loginAttemptKey{
+		{key: accountAttemptKey(email), maxFailures: uc.lockout.MaxAccountFailures, account: true},
+	}
+	if clientIP != "" {
+		keys = append(keys, loginAttemptKey{key: "ip:" + clientIP, maxFailures: uc.lockout.MaxIPFailures})
+	}
+	return keys
+}
+
+func (uc UserUsecase) checkLockout(ctx context.Context, keys []loginAttemptKey) error {
+	now := time.Now()
+	for _, k := range keys {
+		attempts, err := uc.attemptRepo.Get(ctx, k.key)
+		if err != nil {
+			return err
+		}
+		if attempts.Locked(now) {
+			return domain.ErrAccountLocked
+		}
+	}
+	return nil
+}
+
+// loginFailed records the failure for every key, locks the keys that reached their threshold
+// and returns loginErr
+func (uc UserUsecase) loginFailed(ctx context.Context, keys []loginAttemptKey, loginErr error) error {
+	now := time.Now()
+	for _, k := range keys {
+		attempts, err := uc.attemptRepo.RecordFailure(ctx, k.key, now, uc.lockout.FailureWindow, uc.lockout.ResetAfter)
+		if err != nil {
+			return err
+		}
+
+		if attempts.Failures >= k.maxFailures {
+			until := now.Add(uc.lockout.Duration(attempts.Lockouts))
+			if err = uc.attemptRepo.Lock(ctx, k.key, until, uc.lockout.ResetAfter); err != nil {
+				return err
+			}
+		}
+	}
+	return loginErr
+}
+
+// loginSucceeded clears the account history, client IP failures are only forgotten with time
+// so that an attacker cannot reset them by logging into their own account
+func (uc UserUsecase) loginSucceeded(ctx context.Context, keys []loginAttemptKey) error {
+	for _, k := range keys {
+		if !k.account {
+			continue
+		}
+		if err := uc.attemptRepo.Reset(ctx, k.key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnlockAccount lifts the lockout of the account and forgets its failed attempts
+func (uc UserUsecase) UnlockAccount(ctx context.Context, userID uint64) error {
+	user, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{ID: &userID})
+	if err != nil {
+		return err
+	}
+
+	return uc.attemptRepo.Reset(ctx, accountAttemptKey(user.Email))
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"time"
+)
+
+// RequestPasswordReset sends a single-use reset token to the account's email.
+// Unknown emails are silently ignored so the response does not reveal which accounts exist.
+func (uc UserUsecase) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{Email: &email})
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// only the most recently requested token stays valid
+	if err = uc.resetRepo.DeleteByUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	rawToken, tokenHash, err := newSecretToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = uc.resetRepo.Create(ctx, domain.PasswordResetToken{
+		TokenHash: tokenHash,
+		UserID:    user.ID,
+		ExpiresAt: now.Add(uc.resetTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return err
+	}
+
+	return uc.notifier.Notify(ctx, domain.Notification{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the following token to reset your password: %s\nIt expires in %s.",
+			user.Name, rawToken, uc.resetTTL),
+	})
+}
+
+// ResetPassword consumes a reset token and replaces the password of its owner. The password policy
+// is checked before the token is used up, so a rejected password can be retried with the same token.
+func (uc UserUsecase) ResetPassword(ctx context.Context, rawToken, newPassword string) error {
+	tokenHash := hashSecretToken(rawToken)
+	token, err := uc.resetRepo.Get(ctx, tokenHash, time.Now())
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{ID: &token.UserID})
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.ErrInvalidToken
+		}
+		return err
+	}
+
+	if err = uc.validatePassword(newPassword, user); err != nil {
+		return err
+	}
+
+	token, err = uc.resetRepo.Consume(ctx, tokenHash, time.Now())
+	if err != nil {
+		return err
+	}
+
+	hashed, err := uc.pHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = uc.userRepo.Update(ctx, domain.UserFilter{ID: &token.UserID}, domain.UserUpdate{
+		HashedPassword: &hashed,
+		UpdatedAt:      &now,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.ErrInvalidToken
+		}
+		return err
+	}
+
+	// whoever knew the old password is signed out as well
+	if _, err = uc.sessionRepo.RevokeAll(ctx, token.UserID, "", now); err != nil {
+		return err
+	}
+
+	return uc.resetRepo.DeleteByUser(ctx, token.UserID)
+}
+
+// newSecretToken generates a random url-safe token along with the hash that is persisted instead of it
+func newSecretToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	rawToken := base64.RawURLEncoding.EncodeToString(b)
+	return rawToken, hashSecretToken(rawToken), nil
+}
+
+func hashSecretToken(rawToken string) string {
+	sum := sha256.Sum256([]byte(rawToken))
+	return hex.EncodeToString(sum[:])
+}
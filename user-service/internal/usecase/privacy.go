@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"log"
+	"time"
+)
+
+// erasedUserName replaces the name of users whose personal data has been erased
+const erasedUserName = "Deleted user"
+
+// PrivacyUsecase serves data access and erasure requests of users
+type PrivacyUsecase struct {
+	userRepo    UserRepo
+	addressRepo AddressRepo
+	sessionRepo SessionRepo
+	apiKeyRepo  APIKeyRepo
+	resetRepo   PasswordResetRepo
+	verifyRepo  EmailVerificationRepo
+	attemptRepo LoginAttemptRepo
+	orders      OrderClient
+	events      EventPublisher
+}
+
+func NewPrivacyUsecase(
+	userRepo UserRepo,
+	addressRepo AddressRepo,
+	sessionRepo SessionRepo,
+	apiKeyRepo APIKeyRepo,
+	resetRepo PasswordResetRepo,
+	verifyRepo EmailVerificationRepo,
+	attemptRepo LoginAttemptRepo,
+	orders OrderClient,
+	events EventPublisher,
+) PrivacyUsecase {
+	return PrivacyUsecase{
+		userRepo:    userRepo,
+		addressRepo: addressRepo,
+		sessionRepo: sessionRepo,
+		apiKeyRepo:  apiKeyRepo,
+		resetRepo:   resetRepo,
+		verifyRepo:  verifyRepo,
+		attemptRepo: attemptRepo,
+		orders:      orders,
+		events:      events,
+	}
+}
+
+// Export collects the profile, address book, sessions, API keys and the order history of the user
+func (uc PrivacyUsecase) Export(ctx context.Context, userID uint64) (domain.DataExport, error) {
+	user, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{ID: &userID})
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+
+	addresses, err := uc.addressRepo.GetListWithFilter(ctx, domain.AddressFilter{UserID: &userID})
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+
+	sessions, err := uc.sessionRepo.GetListWithFilter(ctx, domain.SessionFilter{UserID: &userID})
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+
+	apiKeys, err := uc.apiKeyRepo.GetListWithFilter(ctx, domain.APIKeyFilter{OwnerID: &userID})
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+
+	orders, err := uc.orders.ListUserOrders(ctx, userID)
+	if err != nil {
+		return domain.DataExport{}, fmt.Errorf("failed to fetch order history: %w", err)
+	}
+
+	return domain.DataExport{
+		User:        user,
+		Addresses:   addresses,
+		Sessions:    sessions,
+		APIKeys:     apiKeys,
+		Orders:      orders,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+// Erase removes the personal data of the user. The orders are detached from the user first so that
+// a failure leaves the account intact and the erasure can be retried; their amounts are kept for
+// accounting. The user record itself stays anonymized to keep its id from being reused.
+// Returns the number of orders that were detached.
+func (uc PrivacyUsecase) Erase(ctx context.Context, userID uint64) (int64, error) {
+	user, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{ID: &userID})
+	if err != nil {
+		return 0, err
+	}
+
+	orders, err := uc.orders.PseudonymizeUserOrders(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to pseudonymize orders: %w", err)
+	}
+
+	now := time.Now()
+	name := erasedUserName
+	email := fmt.Sprintf("erased-%d@invalid", userID) // emails are unique, so every erased user gets its own
+	hashedPassword := ""
+	emailVerified := false
+	err = uc.userRepo.Update(ctx, domain.UserFilter{ID: &userID}, domain.UserUpdate{
+		Name:           &name,
+		Email:          &email,
+		HashedPassword: &hashedPassword,
+		EmailVerified:  &emailVerified,
+		TwoFactor:      &domain.TwoFactor{},
+		UpdatedAt:      &now,
+		ErasedAt:       &now,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err = uc.addressRepo.DeleteByUser(ctx, userID); err != nil {
+		return 0, err
+	}
+	if err = uc.sessionRepo.DeleteByUser(ctx, userID); err != nil {
+		return 0, err
+	}
+	if err = uc.apiKeyRepo.RevokeAll(ctx, userID, now); err != nil {
+		return 0, err
+	}
+	if err = uc.resetRepo.DeleteByUser(ctx, userID); err != nil {
+		return 0, err
+	}
+	if err = uc.verifyRepo.DeleteByUser(ctx, userID); err != nil {
+		return 0, err
+	}
+	if err = uc.attemptRepo.Reset(ctx, accountAttemptKey(user.Email)); err != nil {
+		return 0, err
+	}
+
+	if err = uc.events.PublishUserDeleted(ctx, userID, true); err != nil {
+		log.Printf("failed to publish erasure of user %d: %v", userID, err)
+	}
+	return orders, nil
+}
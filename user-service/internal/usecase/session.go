@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"log"
+	"time"
+)
+
+const (
+	// sessionTouchInterval limits how often validating a session rewrites its last seen time
+	sessionTouchInterval = time.Minute
+	maxUserAgentLength   = 256
+)
+
+// startSession opens a session for a completed login and issues its first token pair
+func (uc UserUsecase) startSession(ctx context.Context, user domain.User, twoFactor bool, client domain.ClientInfo) (domain.TokenPair, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return domain.TokenPair{}, fmt.Errorf("failed to generate session id: %w", err)
+	}
+	sessionID := hex.EncodeToString(b)
+
+	tokens, err := uc.issueTokens(user, twoFactor, sessionID)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	now := time.Now()
+	err = uc.sessionRepo.Create(ctx, domain.Session{
+		ID:               sessionID,
+		UserID:           user.ID,
+		UserAgent:        userAgent,
+		IP:               client.IP,
+		TwoFactor:        twoFactor,
+		RefreshTokenHash: hashSecretToken(tokens.RefreshToken),
+		CreatedAt:        now,
+		LastSeenAt:       now,
+		ExpiresAt:        now.Add(uc.tokens.RefreshTTL()),
+	})
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	return tokens, nil
+}
+
+// ValidateSession reports whether tokens of the session are still accepted and records the activity
+func (uc UserUsecase) ValidateSession(ctx context.Context, sessionID string) (domain.Session, error) {
+	filter := domain.SessionFilter{ID: &sessionID}
+	session, err := uc.sessionRepo.GetWithFilter(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return domain.Session{}, domain.ErrInvalidToken
+		}
+		return domain.Session{}, err
+	}
+
+	now := time.Now()
+	if !session.Active(now) {
+		return domain.Session{}, domain.ErrInvalidToken
+	}
+
+	// the timestamp is informational, a failed write does not reject the request
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		if err = uc.sessionRepo.Update(ctx, filter, domain.SessionUpdate{LastSeenAt: &now}); err != nil {
+			log.Printf("failed to record activity of session %s: %v", sessionID, err)
+		}
+		session.LastSeenAt = now
+	}
+
+	return session, nil
+}
+
+// ListSessions returns the user's sessions that have not been revoked or expired, most recently used first
+func (uc UserUsecase) ListSessions(ctx context.Context, userID uint64) ([]domain.Session, error) {
+	sessions, err := uc.sessionRepo.GetListWithFilter(ctx, domain.SessionFilter{UserID: &userID})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := make([]domain.Session, 0, len(sessions))
+	for _, session := range sessions {
+		if session.Active(now) {
+			active = append(active, session)
+		}
+	}
+	return active, nil
+}
+
+// RevokeSession signs one of the user's devices out
+func (uc UserUsecase) RevokeSession(ctx context.Context, userID uint64, sessionID string) error {
+	filter := domain.SessionFilter{ID: &sessionID, UserID: &userID}
+	session, err := uc.sessionRepo.GetWithFilter(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if session.Revoked() {
+		return nil
+	}
+
+	now := time.Now()
+	return uc.sessionRepo.Update(ctx, filter, domain.SessionUpdate{RevokedAt: &now})
+}
+
+// RevokeAllSessions signs the user out on every device except the session given in keepSessionID,
+// which may be empty
+func (uc UserUsecase) RevokeAllSessions(ctx context.Context, userID uint64, keepSessionID string) (int64, error) {
+	if _, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{ID: &userID}); err != nil {
+		return 0, err
+	}
+
+	return uc.sessionRepo.RevokeAll(ctx, userID, keepSessionID, time.Now())
+}
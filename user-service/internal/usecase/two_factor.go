@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"strings"
+	"time"
+)
+
+const recoveryCodeCount = 10
+
+// EnrollTwoFactor generates a new TOTP secret for the user, it only takes effect once confirmed with ConfirmTwoFactor
+func (uc UserUsecase) EnrollTwoFactor(ctx context.Context, userID uint64) (domain.TwoFactorEnrollment, error) {
+	filter := domain.UserFilter{ID: &userID}
+	user, err := uc.userRepo.GetWithFilter(ctx, filter)
+	if err != nil {
+		return domain.TwoFactorEnrollment{}, err
+	}
+
+	if user.TwoFactor.Enabled {
+		return domain.TwoFactorEnrollment{}, domain.ErrTwoFactorEnabled
+	}
+
+	secret, uri, err := uc.totp.GenerateSecret(user.Email)
+	if err != nil {
+		return domain.TwoFactorEnrollment{}, err
+	}
+
+	now := time.Now()
+	err = uc.userRepo.Update(ctx, filter, domain.UserUpdate{
+		TwoFactor: &domain.TwoFactor{PendingSecret: secret},
+		UpdatedAt: &now,
+	})
+	if err != nil {
+		return domain.TwoFactorEnrollment{}, err
+	}
+
+	return domain.TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: uri,
+	}, nil
+}
+
+// ConfirmTwoFactor enables two-factor authentication once the user proves their app generates valid codes,
+// the returned recovery codes are shown only once
+func (uc UserUsecase) ConfirmTwoFactor(ctx context.Context, userID uint64, code string) ([]string, error) {
+	filter := domain.UserFilter{ID: &userID}
+	user, err := uc.userRepo.GetWithFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactor.Enabled {
+		return nil, domain.ErrTwoFactorEnabled
+	}
+	if user.TwoFactor.PendingSecret == "" {
+		return nil, domain.ErrTwoFactorNotEnabled
+	}
+
+	step, ok := uc.totp.Validate(user.TwoFactor.PendingSecret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return nil, domain.ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = uc.userRepo.Update(ctx, filter, domain.UserUpdate{
+		TwoFactor: &domain.TwoFactor{
+			Enabled:       true,
+			Secret:        user.TwoFactor.PendingSecret,
+			RecoveryCodes: hashes,
+			LastUsedStep:  step,
+		},
+		UpdatedAt: &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off, it requires a current TOTP or recovery code
+func (uc UserUsecase) DisableTwoFactor(ctx context.Context, userID uint64, code string) error {
+	filter := domain.UserFilter{ID: &userID}
+	user, err := uc.userRepo.GetWithFilter(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if !user.TwoFactor.Enabled {
+		return domain.ErrTwoFactorNotEnabled
+	}
+
+	if err = uc.verifySecondFactor(ctx, user, code); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return uc.userRepo.Update(ctx, filter, domain.UserUpdate{
+		TwoFactor: &domain.TwoFactor{},
+		UpdatedAt: &now,
+	})
+}
+
+// VerifyTwoFactorLogin completes a login started by Login, exchanging the two-factor token and a code for a token pair
+func (uc UserUsecase) VerifyTwoFactorLogin(ctx context.Context, twoFactorToken, code string, client domain.ClientInfo) (domain.TokenPair, error) {
+	claims, err := uc.tokens.Parse(twoFactorToken, domain.TwoFactorToken)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	user, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{ID: &claims.UserID})
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.TokenPair{}, domain.ErrInvalidToken
+		}
+		return domain.TokenPair{}, err
+	}
+
+	if !user.TwoFactor.Enabled {
+		return domain.TokenPair{}, domain.ErrInvalidToken
+	}
+
+	keys := uc.loginAttemptKeys(user.Email, client.IP)
+	if err = uc.checkLockout(ctx, keys); err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	if err = uc.checkSecondFactor(ctx, user, code, keys); err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	if err = uc.loginSucceeded(ctx, keys); err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	return uc.startSession(ctx, user, true, client)
+}
+
+// checkSecondFactor verifies the code during a login, wrong codes count towards the lockout like wrong passwords
+func (uc UserUsecase) checkSecondFactor(ctx context.Context, user domain.User, code string, keys []loginAttemptKey) error {
+	err := uc.verifySecondFactor(ctx, user, code)
+	if errors.Is(err, domain.ErrInvalidTwoFactorCode) {
+		return uc.loginFailed(ctx, keys, domain.ErrInvalidTwoFactorCode)
+	}
+	return err
+}
+
+// verifySecondFactor accepts a TOTP code that has not been used yet or an unused recovery code
+func (uc UserUsecase) verifySecondFactor(ctx context.Context, user domain.User, code string) error {
+	code = strings.TrimSpace(code)
+
+	if step, ok := uc.totp.Validate(user.TwoFactor.Secret, code, time.Now()); ok {
+		return uc.userRepo.UseTwoFactorStep(ctx, user.ID, step)
+	}
+
+	return uc.userRepo.UseRecoveryCode(ctx, user.ID, hashSecretToken(normalizeRecoveryCode(code)))
+}
+
+func (uc UserUsecase) issueTwoFactorChallenge(user domain.User) (domain.TokenPair, error) {
+	token, err := uc.tokens.Issue(domain.TokenClaims{
+		UserID: user.ID,
+		Type:   domain.TwoFactorToken,
+	})
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	return domain.TokenPair{
+		UserID:            user.ID,
+		TwoFactorRequired: true,
+		TwoFactorToken:    token,
+	}, nil
+}
+
+// newRecoveryCodes generates codes formatted as xxxx-xxxx-xxxx-xxxx along with the hashes to store
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		raw := strings.ToLower(base32.StdEncoding.EncodeToString(b))
+		codes = append(codes, fmt.Sprintf("%s-%s-%s-%s", raw[0:4], raw[4:8], raw[8:12], raw[12:16]))
+		hashes = append(hashes, hashSecretToken(raw))
+	}
+
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
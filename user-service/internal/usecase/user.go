@@ -2,20 +2,69 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"github.com/BeksultanSE/Assignment1-user/internal/domain"
+	"log"
+	"time"
 )
 
 type UserUsecase struct {
-	aiRepo   AutoIncRepo
-	userRepo UserRepo
-	pHasher  PasswordHasher
+	aiRepo      AutoIncRepo
+	userRepo    UserRepo
+	resetRepo   PasswordResetRepo
+	verifyRepo  EmailVerificationRepo
+	attemptRepo LoginAttemptRepo
+	sessionRepo SessionRepo
+	addressRepo AddressRepo
+	apiKeyRepo  APIKeyRepo
+	pHasher     PasswordHasher
+	passwords   PasswordPolicy
+	tokens      TokenManager
+	totp        TOTPProvider
+	notifier    Notifier
+	events      EventPublisher
+	resetTTL    time.Duration
+	verifyTTL   time.Duration
+	lockout     domain.LockoutPolicy
 }
 
-func NewUserUsecase(ai AutoIncRepo, userRepo UserRepo, pHasher PasswordHasher) UserUsecase {
+func NewUserUsecase(
+	ai AutoIncRepo,
+	userRepo UserRepo,
+	resetRepo PasswordResetRepo,
+	verifyRepo EmailVerificationRepo,
+	attemptRepo LoginAttemptRepo,
+	sessionRepo SessionRepo,
+	addressRepo AddressRepo,
+	apiKeyRepo APIKeyRepo,
+	pHasher PasswordHasher,
+	passwords PasswordPolicy,
+	tokens TokenManager,
+	totp TOTPProvider,
+	notifier Notifier,
+	events EventPublisher,
+	resetTTL time.Duration,
+	verifyTTL time.Duration,
+	lockout domain.LockoutPolicy,
+) UserUsecase {
 	return UserUsecase{
-		aiRepo:   ai,
-		userRepo: userRepo,
-		pHasher:  pHasher,
+		aiRepo:      ai,
+		userRepo:    userRepo,
+		resetRepo:   resetRepo,
+		verifyRepo:  verifyRepo,
+		attemptRepo: attemptRepo,
+		sessionRepo: sessionRepo,
+		addressRepo: addressRepo,
+		apiKeyRepo:  apiKeyRepo,
+		pHasher:     pHasher,
+		passwords:   passwords,
+		tokens:      tokens,
+		totp:        totp,
+		notifier:    notifier,
+		events:      events,
+		resetTTL:    resetTTL,
+		verifyTTL:   verifyTTL,
+		lockout:     lockout,
 	}
 }
 
@@ -24,54 +73,114 @@ func (uc UserUsecase) Register(ctx context.Context, req domain.User) (domain.Use
 	emailFilter := domain.UserFilter{
 		Email: &req.Email,
 	}
-	if exists, _ := uc.userRepo.GetWithFilter(ctx, emailFilter); exists != (domain.User{}) {
+	if exists, _ := uc.userRepo.GetWithFilter(ctx, emailFilter); exists.ID != 0 {
 		return domain.User{}, domain.ErrUserExists
 	}
 
+	if err := uc.validatePassword(req.HashedPassword, req); err != nil {
+		return domain.User{}, err
+	}
+
 	id, err := uc.aiRepo.Next(ctx, domain.UserDB)
 	if err != nil {
 		return domain.User{}, err
 	}
 	req.ID = id
 
+	// administrators are promoted with UpdateRole or bootstrapped from the configuration
+	req.Role = domain.RoleCustomer
+
 	req.HashedPassword, err = uc.pHasher.Hash(req.HashedPassword)
 	if err != nil {
 		return domain.User{}, err
 	}
 
+	// accounts stay unverified until the emailed token is confirmed
+	req.EmailVerified = false
+	req.CreatedAt = time.Now()
+	req.UpdatedAt = req.CreatedAt
+
 	err = uc.userRepo.Create(ctx, req)
 	if err != nil {
 		return domain.User{}, err
 	}
 
+	if err = uc.events.PublishUserRegistered(ctx, req); err != nil {
+		log.Printf("failed to publish registration of user %d: %v", req.ID, err)
+	}
+
+	// the account is already created, a failed delivery can be retried with ResendVerificationEmail
+	if err = uc.sendVerification(ctx, req); err != nil {
+		log.Printf("failed to send verification email to user %d: %v", req.ID, err)
+	}
+
 	return domain.User{
 		ID:   id,
 		Name: req.Name,
+		Role: req.Role,
 	}, nil
 }
 
-func (uc UserUsecase) Authenticate(ctx context.Context, req domain.User) (domain.User, error) {
+// Authenticate checks the credentials, unknown emails and wrong passwords both result in
+// domain.ErrInvalidCredentials and count towards the lockout of the email and the client IP.
+// Accounts with two-factor authentication also need a valid TOTP or recovery code.
+func (uc UserUsecase) Authenticate(ctx context.Context, req domain.User, clientIP, twoFactorCode string) (domain.User, error) {
+	user, keys, err := uc.checkPassword(ctx, req, clientIP)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	if user.TwoFactor.Enabled {
+		if twoFactorCode == "" {
+			return domain.User{}, domain.ErrTwoFactorRequired
+		}
+		if err = uc.checkSecondFactor(ctx, user, twoFactorCode, keys); err != nil {
+			return domain.User{}, err
+		}
+	}
+
+	if err = uc.loginSucceeded(ctx, keys); err != nil {
+		return domain.User{}, err
+	}
+
+	return domain.User{
+		ID:   user.ID,
+		Name: user.Name,
+		Role: user.Role,
+	}, nil
+}
+
+// checkPassword is the first login step, it returns the user and the attempt keys tracked for the login
+func (uc UserUsecase) checkPassword(ctx context.Context, req domain.User, clientIP string) (domain.User, []loginAttemptKey, error) {
+	keys := uc.loginAttemptKeys(req.Email, clientIP)
+	if err := uc.checkLockout(ctx, keys); err != nil {
+		return domain.User{}, nil, err
+	}
+
 	emailFilter := domain.UserFilter{
 		Email: &req.Email,
 	}
 	existingUser, err := uc.userRepo.GetWithFilter(ctx, emailFilter)
 	if err != nil {
-		return domain.User{}, err
-	}
-
-	if existingUser == (domain.User{}) {
-		return domain.User{}, domain.ErrUserNotFound
+		if errors.Is(err, domain.ErrUserNotFound) {
+			// spend about as long as a real verification so timing does not reveal the account either
+			_, _ = uc.pHasher.Hash(req.HashedPassword)
+			return domain.User{}, nil, uc.loginFailed(ctx, keys, domain.ErrInvalidCredentials)
+		}
+		return domain.User{}, nil, err
 	}
 
 	isValid := uc.pHasher.Verify(existingUser.HashedPassword, req.HashedPassword)
 	if !isValid {
-		return domain.User{}, domain.ErrInvalidPassword
+		return domain.User{}, nil, uc.loginFailed(ctx, keys, domain.ErrInvalidCredentials)
 	}
 
-	return domain.User{
-		ID:   existingUser.ID,
-		Name: existingUser.Name,
-	}, nil
+	// the plain password is only available here, so outdated hashes are upgraded on login
+	if uc.pHasher.NeedsRehash(existingUser.HashedPassword) {
+		uc.rehashPassword(ctx, existingUser.ID, req.HashedPassword)
+	}
+
+	return existingUser, keys, nil
 }
 
 func (uc UserUsecase) Get(ctx context.Context, filter domain.UserFilter) (domain.User, error) {
@@ -81,3 +190,329 @@ func (uc UserUsecase) Get(ctx context.Context, filter domain.UserFilter) (domain
 	}
 	return user, nil
 }
+
+// List returns a page of users matching the filter together with the total number of matches
+func (uc UserUsecase) List(ctx context.Context, filter domain.UserFilter, sort domain.UserSort, page, limit int64) ([]domain.User, int, error) {
+	users, total, err := uc.userRepo.GetListWithFilter(ctx, filter, sort, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// Login authenticates the user and issues a new access/refresh token pair. If the account uses
+// two-factor authentication and no code was given, a two-factor token is returned instead which
+// is exchanged for the token pair by VerifyTwoFactorLogin. Every login opens a new session.
+func (uc UserUsecase) Login(ctx context.Context, req domain.User, client domain.ClientInfo, twoFactorCode string) (domain.TokenPair, error) {
+	user, keys, err := uc.checkPassword(ctx, req, client.IP)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	if user.TwoFactor.Enabled {
+		if twoFactorCode == "" {
+			return uc.issueTwoFactorChallenge(user)
+		}
+		if err = uc.checkSecondFactor(ctx, user, twoFactorCode, keys); err != nil {
+			return domain.TokenPair{}, err
+		}
+	}
+
+	if err = uc.loginSucceeded(ctx, keys); err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	return uc.startSession(ctx, user, user.TwoFactor.Enabled, client)
+}
+
+// RefreshToken exchanges a valid refresh token for a new token pair of the same session. The refresh
+// token is single use, presenting an already used one revokes the session as the token may have been stolen.
+func (uc UserUsecase) RefreshToken(ctx context.Context, refreshToken string, client domain.ClientInfo) (domain.TokenPair, error) {
+	claims, err := uc.tokens.Parse(refreshToken, domain.RefreshToken)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	// tokens issued before sessions were introduced cannot be revoked, their owners have to log in again
+	if claims.SessionID == "" {
+		return domain.TokenPair{}, domain.ErrInvalidToken
+	}
+
+	sessionFilter := domain.SessionFilter{ID: &claims.SessionID, UserID: &claims.UserID}
+	session, err := uc.sessionRepo.GetWithFilter(ctx, sessionFilter)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return domain.TokenPair{}, domain.ErrInvalidToken
+		}
+		return domain.TokenPair{}, err
+	}
+
+	now := time.Now()
+	if !session.Active(now) {
+		return domain.TokenPair{}, domain.ErrInvalidToken
+	}
+
+	// the account may have been removed since the token was issued
+	user, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{ID: &claims.UserID})
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.TokenPair{}, domain.ErrInvalidToken
+		}
+		return domain.TokenPair{}, err
+	}
+
+	// a refresh keeps the second factor of the original login, unless it was disabled since
+	tokens, err := uc.issueTokens(user, claims.TwoFactor && user.TwoFactor.Enabled, session.ID)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	err = uc.sessionRepo.Rotate(
+		ctx,
+		session.ID,
+		hashSecretToken(refreshToken),
+		hashSecretToken(tokens.RefreshToken),
+		client.IP,
+		now.Add(uc.tokens.RefreshTTL()),
+		now,
+	)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidToken) {
+			log.Printf("refresh token of session %s was reused, revoking the session", session.ID)
+			if revokeErr := uc.sessionRepo.Update(ctx, sessionFilter, domain.SessionUpdate{RevokedAt: &now}); revokeErr != nil {
+				log.Printf("failed to revoke session %s: %v", session.ID, revokeErr)
+			}
+		}
+		return domain.TokenPair{}, err
+	}
+
+	return tokens, nil
+}
+
+// UpdateRole assigns a new role to the user and returns the updated profile
+func (uc UserUsecase) UpdateRole(ctx context.Context, userID uint64, role domain.Role) (domain.User, error) {
+	if !role.Valid() {
+		return domain.User{}, domain.ErrInvalidRole
+	}
+
+	filter := domain.UserFilter{ID: &userID}
+	now := time.Now()
+	err := uc.userRepo.Update(ctx, filter, domain.UserUpdate{
+		Role:      &role,
+		UpdatedAt: &now,
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	user, err := uc.userRepo.GetWithFilter(ctx, filter)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	uc.publishUpdated(ctx, user)
+	return user, nil
+}
+
+// BootstrapAdmin promotes the account with the configured email to administrator.
+// The account has to be registered and its email verified first, so nobody can claim it by registering the address.
+func (uc UserUsecase) BootstrapAdmin(ctx context.Context, email string) error {
+	user, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{Email: &email})
+	if err != nil {
+		return err
+	}
+	if !user.EmailVerified {
+		return domain.ErrEmailUnverified
+	}
+	if user.Role == domain.RoleAdmin {
+		return nil
+	}
+
+	_, err = uc.UpdateRole(ctx, user.ID, domain.RoleAdmin)
+	return err
+}
+
+// UpdateProfile changes the user's name and/or email and returns the updated profile
+func (uc UserUsecase) UpdateProfile(ctx context.Context, userID uint64, update domain.UserUpdate) (domain.User, error) {
+	filter := domain.UserFilter{ID: &userID}
+
+	if update.Email != nil {
+		existing, err := uc.userRepo.GetWithFilter(ctx, domain.UserFilter{Email: update.Email})
+		if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+			return domain.User{}, err
+		}
+		if err == nil && existing.ID != userID {
+			return domain.User{}, domain.ErrUserExists
+		}
+	}
+
+	current, err := uc.userRepo.GetWithFilter(ctx, filter)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	now := time.Now()
+	userUpdate := domain.UserUpdate{
+		Name:      update.Name,
+		Email:     update.Email,
+		UpdatedAt: &now,
+	}
+
+	// a new address has to be verified again
+	emailChanged := update.Email != nil && *update.Email != current.Email
+	if emailChanged {
+		verified := false
+		userUpdate.EmailVerified = &verified
+	}
+
+	err = uc.userRepo.Update(ctx, filter, userUpdate)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	user, err := uc.userRepo.GetWithFilter(ctx, filter)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	if emailChanged {
+		if err = uc.sendVerification(ctx, user); err != nil {
+			log.Printf("failed to send verification email to user %d: %v", user.ID, err)
+		}
+	}
+
+	uc.publishUpdated(ctx, user)
+	return user, nil
+}
+
+// ChangePassword replaces the password after verifying the current one
+func (uc UserUsecase) ChangePassword(ctx context.Context, userID uint64, currentPassword, newPassword, currentSessionID string) error {
+	filter := domain.UserFilter{ID: &userID}
+	user, err := uc.userRepo.GetWithFilter(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if !uc.pHasher.Verify(user.HashedPassword, currentPassword) {
+		return domain.ErrInvalidPassword
+	}
+
+	if err = uc.validatePassword(newPassword, user); err != nil {
+		return err
+	}
+
+	hashed, err := uc.pHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = uc.userRepo.Update(ctx, filter, domain.UserUpdate{
+		HashedPassword: &hashed,
+		UpdatedAt:      &now,
+	})
+	if err != nil {
+		return err
+	}
+
+	// other devices are signed out, the session changing the password stays logged in
+	_, err = uc.sessionRepo.RevokeAll(ctx, userID, currentSessionID, now)
+	return err
+}
+
+// Delete permanently removes the user account with its address book, revokes its API keys and signs it out everywhere
+func (uc UserUsecase) Delete(ctx context.Context, userID uint64) error {
+	if err := uc.userRepo.Delete(ctx, domain.UserFilter{ID: &userID}); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if _, err := uc.sessionRepo.RevokeAll(ctx, userID, "", now); err != nil {
+		return err
+	}
+
+	// the address book and the keys acting for the user must not outlive the account
+	if err := uc.addressRepo.DeleteByUser(ctx, userID); err != nil {
+		return err
+	}
+	if err := uc.apiKeyRepo.RevokeAll(ctx, userID, now); err != nil {
+		return err
+	}
+
+	if err := uc.events.PublishUserDeleted(ctx, userID, false); err != nil {
+		log.Printf("failed to publish deletion of user %d: %v", userID, err)
+	}
+	return nil
+}
+
+func (uc UserUsecase) PublicKey() domain.PublicKey {
+	return uc.tokens.PublicKey()
+}
+
+func (uc UserUsecase) issueTokens(user domain.User, twoFactor bool, sessionID string) (domain.TokenPair, error) {
+	accessToken, err := uc.tokens.Issue(domain.TokenClaims{
+		UserID:    user.ID,
+		Role:      user.Role,
+		Type:      domain.AccessToken,
+		TwoFactor: twoFactor,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	refreshToken, err := uc.tokens.Issue(domain.TokenClaims{
+		UserID:    user.ID,
+		Type:      domain.RefreshToken,
+		TwoFactor: twoFactor,
+		SessionID: sessionID,
+	})
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+
+	return domain.TokenPair{
+		UserID:       user.ID,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    uc.tokens.AccessTTL(),
+	}, nil
+}
+
+// rehashPassword stores the password with the current hashing settings, failures only cost the upgrade
+func (uc UserUsecase) rehashPassword(ctx context.Context, userID uint64, password string) {
+	hashed, err := uc.pHasher.Hash(password)
+	if err != nil {
+		log.Printf("failed to rehash password of user %d: %v", userID, err)
+		return
+	}
+
+	now := time.Now()
+	err = uc.userRepo.Update(ctx, domain.UserFilter{ID: &userID}, domain.UserUpdate{
+		HashedPassword: &hashed,
+		UpdatedAt:      &now,
+	})
+	if err != nil {
+		log.Printf("failed to store rehashed password of user %d: %v", userID, err)
+	}
+}
+
+// publishUpdated announces a profile change, the change is already stored so a failure is only logged
+func (uc UserUsecase) publishUpdated(ctx context.Context, user domain.User) {
+	if err := uc.events.PublishUserUpdated(ctx, user); err != nil {
+		log.Printf("failed to publish update of user %d: %v", user.ID, err)
+	}
+}
+
+// validatePassword applies the password policy to a new password of the user,
+// violations are returned as a domain.PasswordPolicyError
+func (uc UserUsecase) validatePassword(password string, user domain.User) error {
+	violations, err := uc.passwords.Check(password, user)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return domain.PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package hashing
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+// argon2idParams are encoded into every hash, so changing the configuration does not break old hashes
+type argon2idParams struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	saltLen uint32
+	keyLen  uint32
+}
+
+// Argon2idHasher produces hashes in the PHC string format: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+type Argon2idHasher struct {
+	params argon2idParams
+}
+
+func NewArgon2idHasher(cfg Config) (*Argon2idHasher, error) {
+	if cfg.Argon2Memory == 0 || cfg.Argon2Time == 0 || cfg.Argon2Threads == 0 {
+		return nil, errors.New("argon2id memory, time and threads must be positive")
+	}
+	if cfg.Argon2SaltLen < 8 || cfg.Argon2KeyLen < 16 {
+		return nil, errors.New("argon2id salt must be at least 8 bytes and key at least 16 bytes")
+	}
+
+	return &Argon2idHasher{
+		params: argon2idParams{
+			memory:  cfg.Argon2Memory,
+			time:    cfg.Argon2Time,
+			threads: cfg.Argon2Threads,
+			saltLen: cfg.Argon2SaltLen,
+			keyLen:  cfg.Argon2KeyLen,
+		},
+	}, nil
+}
+
+func (a *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, a.params.saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	p := a.params
+	key := argon2.IDKey([]byte(password), salt, p.time, p.memory, p.threads, p.keyLen)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		AlgorithmArgon2id, argon2.Version, p.memory, p.time, p.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (a *Argon2idHasher) Verify(hash, password string) bool {
+	p, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return false
+	}
+
+	other := argon2.IDKey([]byte(password), salt, p.time, p.memory, p.threads, p.keyLen)
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+// NeedsRehash reports whether the hash was made with parameters other than the configured ones
+func (a *Argon2idHasher) NeedsRehash(hash string) bool {
+	p, _, _, err := decodeArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return p.memory != a.params.memory ||
+		p.time != a.params.time ||
+		p.threads != a.params.threads ||
+		p.keyLen != a.params.keyLen
+}
+
+func (a *Argon2idHasher) Handles(hash string) bool {
+	return strings.HasPrefix(hash, "$"+AlgorithmArgon2id+"$")
+}
+
+func decodeArgon2id(hash string) (argon2idParams, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return argon2idParams{}, nil, nil, errors.New("invalid argon2id hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("invalid argon2id version: %w", err)
+	}
+	if version != argon2.Version {
+		return argon2idParams{}, nil, nil, fmt.Errorf("unsupported argon2id version: %d", version)
+	}
+
+	var p argon2idParams
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("invalid argon2id parameters: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("invalid argon2id salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("invalid argon2id key: %w", err)
+	}
+
+	p.saltLen = uint32(len(salt))
+	p.keyLen = uint32(len(key))
+	return p, salt, key, nil
+}
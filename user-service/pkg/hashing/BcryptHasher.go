@@ -1,16 +1,24 @@
 package hashing
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
 
 type BcryptHasher struct {
+	cost int
 }
 
-func NewBcryptHasher() *BcryptHasher {
-	return &BcryptHasher{}
+func NewBcryptHasher(cost int) (*BcryptHasher, error) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	return &BcryptHasher{cost: cost}, nil
 }
 
 func (b *BcryptHasher) Hash(password string) (string, error) {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
 	return string(hash), err
 }
 
@@ -18,3 +26,14 @@ func (b *BcryptHasher) Verify(hash, password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+// NeedsRehash reports whether the hash was made with a lower cost than the configured one
+func (b *BcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost < b.cost
+}
+
+// Handles reports whether the hash is in the modular crypt format used by bcrypt ($2a$, $2b$, $2y$)
+func (b *BcryptHasher) Handles(hash string) bool {
+	return strings.HasPrefix(hash, "$2")
+}
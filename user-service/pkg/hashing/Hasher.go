@@ -0,0 +1,74 @@
+package hashing
+
+import "fmt"
+
+// algorithmHasher is implemented by every supported hashing algorithm
+type algorithmHasher interface {
+	Hash(password string) (string, error)
+	Verify(hash, password string) bool
+	NeedsRehash(hash string) bool
+	Handles(hash string) bool
+}
+
+// Hasher creates new hashes with the configured algorithm and verifies hashes of any supported algorithm,
+// the algorithm of a stored hash is recognised from its prefix
+type Hasher struct {
+	preferred algorithmHasher
+	known     []algorithmHasher
+}
+
+func NewHasher(cfg Config) (*Hasher, error) {
+	bcryptHasher, err := NewBcryptHasher(cfg.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+	argon2idHasher, err := NewArgon2idHasher(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var preferred algorithmHasher
+	switch cfg.Algorithm {
+	case AlgorithmBcrypt:
+		preferred = bcryptHasher
+	case AlgorithmArgon2id:
+		preferred = argon2idHasher
+	default:
+		return nil, fmt.Errorf("unsupported hashing algorithm: %q", cfg.Algorithm)
+	}
+
+	return &Hasher{
+		preferred: preferred,
+		known:     []algorithmHasher{argon2idHasher, bcryptHasher},
+	}, nil
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.preferred.Hash(password)
+}
+
+func (h *Hasher) Verify(hash, password string) bool {
+	algorithm := h.algorithmOf(hash)
+	if algorithm == nil {
+		return false
+	}
+	return algorithm.Verify(hash, password)
+}
+
+// NeedsRehash reports whether the hash should be replaced because it was made
+// with another algorithm or with weaker parameters than the configured ones
+func (h *Hasher) NeedsRehash(hash string) bool {
+	if !h.preferred.Handles(hash) {
+		return true
+	}
+	return h.preferred.NeedsRehash(hash)
+}
+
+func (h *Hasher) algorithmOf(hash string) algorithmHasher {
+	for _, algorithm := range h.known {
+		if algorithm.Handles(hash) {
+			return algorithm
+		}
+	}
+	return nil
+}
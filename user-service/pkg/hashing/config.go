@@ -0,0 +1,16 @@
+package hashing
+
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+type Config struct {
+	Algorithm     string `env:"HASH_ALGORITHM" envDefault:"argon2id"` // algorithm for new hashes: bcrypt or argon2id
+	BcryptCost    int    `env:"HASH_BCRYPT_COST" envDefault:"12"`
+	Argon2Memory  uint32 `env:"HASH_ARGON2_MEMORY" envDefault:"65536"` // KiB
+	Argon2Time    uint32 `env:"HASH_ARGON2_TIME" envDefault:"3"`
+	Argon2Threads uint8  `env:"HASH_ARGON2_THREADS" envDefault:"2"`
+	Argon2SaltLen uint32 `env:"HASH_ARGON2_SALT_LENGTH" envDefault:"16"`
+	Argon2KeyLen  uint32 `env:"HASH_ARGON2_KEY_LENGTH" envDefault:"32"`
+}